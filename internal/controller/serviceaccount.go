@@ -0,0 +1,38 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetControllerUsername returns the username the controller authenticates as in API requests, which is the username
+// of the service account of the pod the controller is running in
+func GetControllerUsername(ctx context.Context, client crclient.Client) (string, error) {
+	ns, err := k8sutil.GetOperatorNamespace()
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := k8sutil.GetPod(ctx, client, ns)
+	if err != nil {
+		return "", err
+	}
+	serviceAccount := pod.Spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = "default"
+	}
+	return fmt.Sprintf("system:serviceaccount:%s:%s", ns, serviceAccount), nil
+}
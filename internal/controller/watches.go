@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// InformerFunc returns the informer for a kind of objects from an informer factory, e.g.
+// factory.Core().V1().Secrets().Informer()
+type InformerFunc func(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer
+
+func SecretInformer(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer {
+	return factory.Core().V1().Secrets().Informer()
+}
+
+func ConfigMapInformer(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer {
+	return factory.Core().V1().ConfigMaps().Informer()
+}
+
+func PodInformer(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer {
+	return factory.Core().V1().Pods().Informer()
+}
+
+func DaemonSetInformer(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer {
+	return factory.Apps().V1().DaemonSets().Informer()
+}
+
+func DeploymentInformer(factory informers.SharedInformerFactory) toolscache.SharedIndexInformer {
+	return factory.Apps().V1().Deployments().Informer()
+}
+
+// LabelSelectedSources returns sources of events for the objects of a kind that match any of labelSelectors. Unlike
+// the manager's cache, which lists and caches all objects of a kind in the cluster, the informers backing the sources
+// only cache matching objects, so that e.g. unrelated Secrets are never held in the controller's memory. As label
+// selectors cannot be combined with OR, a separate informer is started for each selector.
+func LabelSelectedSources(mgr manager.Manager, informerFor InformerFunc, labelSelectors ...string) ([]source.Source, error) {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	var sources []source.Source
+	for _, labelSelector := range labelSelectors {
+		selector := labelSelector
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = selector
+			}))
+		sources = append(sources, &source.Informer{Informer: informerFor(factory)})
+		err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+			factory.Start(stop)
+			<-stop
+			return nil
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sources, nil
+}
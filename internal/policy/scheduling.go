@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CheckSchedulingAllowed checks that the scheduling settings of a workspace are in the allow-lists from the controller
+// config. Settings of the old workspace are not checked again, so that changes to the allow-lists do not prevent
+// existing workspaces from being updated, e.g. stopped. oldWksp is nil on create and when the controller checks a
+// workspace before starting it, in which case all settings are checked.
+func CheckSchedulingAllowed(oldWksp, newWksp *v1alpha1.Workspace) error {
+	var oldSpec v1alpha1.WorkspaceSpec
+	if oldWksp != nil {
+		oldSpec = oldWksp.Spec
+	}
+	newSpec := newWksp.Spec
+
+	allowedKeys := config.ControllerCfg.GetAllowedNodeSelectorKeys()
+	for key, value := range newSpec.NodeSelector {
+		if oldValue, ok := oldSpec.NodeSelector[key]; ok && oldValue == value {
+			continue
+		}
+		if !config.IsAllowed(allowedKeys, key) {
+			return fmt.Errorf("spec.nodeSelector: node label '%s' is not allowed", key)
+		}
+	}
+
+	allowedKeys = config.ControllerCfg.GetAllowedTolerationKeys()
+	for idx, toleration := range newSpec.Tolerations {
+		if containsToleration(oldSpec, toleration) {
+			continue
+		}
+		if !config.IsAllowed(allowedKeys, toleration.Key) {
+			return fmt.Errorf("spec.tolerations[%d]: tolerations of taint key '%s' are not allowed", idx, toleration.Key)
+		}
+	}
+
+	if newSpec.Affinity != nil && !cmp.Equal(oldSpec.Affinity, newSpec.Affinity) && config.ControllerCfg.GetAllowAffinity() != "true" {
+		return fmt.Errorf("spec.affinity: affinity is not allowed")
+	}
+
+	if newSpec.PriorityClassName != "" && newSpec.PriorityClassName != oldSpec.PriorityClassName &&
+		!config.IsAllowed(config.ControllerCfg.GetAllowedPriorityClasses(), newSpec.PriorityClassName) {
+		return fmt.Errorf("spec.priorityClassName: priority class '%s' is not allowed", newSpec.PriorityClassName)
+	}
+
+	if newSpec.RuntimeClassName != nil && !cmp.Equal(oldSpec.RuntimeClassName, newSpec.RuntimeClassName) &&
+		!config.IsAllowed(config.ControllerCfg.GetAllowedRuntimeClasses(), *newSpec.RuntimeClassName) {
+		return fmt.Errorf("spec.runtimeClassName: runtime class '%s' is not allowed", *newSpec.RuntimeClassName)
+	}
+	return nil
+}
+
+func containsToleration(spec v1alpha1.WorkspaceSpec, toleration corev1.Toleration) bool {
+	for _, existing := range spec.Tolerations {
+		if cmp.Equal(existing, toleration) {
+			return true
+		}
+	}
+	return false
+}
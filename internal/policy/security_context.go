@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package policy
+
+import (
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CheckSecurityContextAllowed checks the security context overrides of a workspace against the security context policy
+// from the controller config. Overrides that are unchanged from the old workspace are not checked again. oldWksp is nil
+// on create and when the controller checks a workspace before starting it, in which case all overrides are checked.
+func CheckSecurityContextAllowed(oldWksp, newWksp *v1alpha1.Workspace) error {
+	var oldSpec v1alpha1.WorkspaceSpec
+	if oldWksp != nil {
+		oldSpec = oldWksp.Spec
+	}
+	newSpec := newWksp.Spec
+	podContextChanged := newSpec.PodSecurityContext != nil && !cmp.Equal(oldSpec.PodSecurityContext, newSpec.PodSecurityContext)
+	containerContextChanged := newSpec.ContainerSecurityContext != nil && !cmp.Equal(oldSpec.ContainerSecurityContext, newSpec.ContainerSecurityContext)
+	if !podContextChanged && !containerContextChanged {
+		return nil
+	}
+
+	switch policy := config.ControllerCfg.GetSecurityContextPolicy(); policy {
+	case config.SecurityContextPolicyAny:
+		return nil
+	case config.SecurityContextPolicyRestricted:
+		if podContextChanged {
+			if err := checkRestrictedPodSecurityContext(newSpec.PodSecurityContext); err != nil {
+				return err
+			}
+		}
+		if containerContextChanged {
+			return checkRestrictedContainerSecurityContext(newSpec.ContainerSecurityContext)
+		}
+		return nil
+	default:
+		if podContextChanged {
+			return fmt.Errorf("spec.podSecurityContext: security context overrides are not allowed")
+		}
+		return fmt.Errorf("spec.containerSecurityContext: security context overrides are not allowed")
+	}
+}
+
+// checkRestrictedPodSecurityContext checks that a pod security context override does not weaken the restricted Pod
+// Security Standard
+func checkRestrictedPodSecurityContext(securityContext *corev1.PodSecurityContext) error {
+	switch {
+	case securityContext.RunAsNonRoot != nil && !*securityContext.RunAsNonRoot:
+		return fmt.Errorf("spec.podSecurityContext.runAsNonRoot: must not be false")
+	case securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0:
+		return fmt.Errorf("spec.podSecurityContext.runAsUser: must not be 0")
+	case securityContext.SELinuxOptions != nil:
+		return fmt.Errorf("spec.podSecurityContext.seLinuxOptions: must not be set")
+	case len(securityContext.Sysctls) > 0:
+		return fmt.Errorf("spec.podSecurityContext.sysctls: must not be set")
+	}
+	return nil
+}
+
+// checkRestrictedContainerSecurityContext checks that a container security context override does not weaken the
+// restricted Pod Security Standard
+func checkRestrictedContainerSecurityContext(securityContext *corev1.SecurityContext) error {
+	switch {
+	case securityContext.Privileged != nil && *securityContext.Privileged:
+		return fmt.Errorf("spec.containerSecurityContext.privileged: must not be true")
+	case securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation:
+		return fmt.Errorf("spec.containerSecurityContext.allowPrivilegeEscalation: must not be true")
+	case securityContext.RunAsNonRoot != nil && !*securityContext.RunAsNonRoot:
+		return fmt.Errorf("spec.containerSecurityContext.runAsNonRoot: must not be false")
+	case securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0:
+		return fmt.Errorf("spec.containerSecurityContext.runAsUser: must not be 0")
+	case securityContext.SELinuxOptions != nil:
+		return fmt.Errorf("spec.containerSecurityContext.seLinuxOptions: must not be set")
+	case securityContext.ProcMount != nil && *securityContext.ProcMount != corev1.DefaultProcMount:
+		return fmt.Errorf("spec.containerSecurityContext.procMount: must be %s", corev1.DefaultProcMount)
+	}
+	if capabilities := securityContext.Capabilities; capabilities != nil {
+		for idx, capability := range capabilities.Add {
+			if capability != "NET_BIND_SERVICE" {
+				return fmt.Errorf("spec.containerSecurityContext.capabilities.add[%d]: only NET_BIND_SERVICE may be added", idx)
+			}
+		}
+		if capabilities.Drop != nil {
+			return fmt.Errorf("spec.containerSecurityContext.capabilities.drop: must not be set, as it replaces the capabilities dropped by default")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsAdmitted returns whether a workspace counts against quotas: it is started and was admitted by the quota check,
+// or it was already running when quotas were configured.
+func IsAdmitted(workspace *v1alpha1.Workspace) bool {
+	if !workspace.Spec.Started || workspace.Status.Phase == v1alpha1.WorkspaceStatusFailed {
+		return false
+	}
+	if workspace.Status.Phase == v1alpha1.WorkspaceStatusRunning {
+		return true
+	}
+	for _, condition := range workspace.Status.Conditions {
+		if condition.Type == v1alpha1.WorkspaceQuotaAvailable {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Check returns a message describing the exceeded quota if starting the workspace would exceed the quotas of its
+// namespace or of its creator, or an empty string if the workspace may be started. The memory of the workspace is
+// taken from its status, which is computed by the controller; until it is known, the workspace is only rejected if
+// the memory quota is already used up. Workspaces are listed with reader, which should read from the API server
+// rather than from a cache, so that workspaces admitted just before are counted.
+func Check(ctx context.Context, reader crclient.Reader, workspace *v1alpha1.Workspace) (string, error) {
+	quota, err := config.ControllerCfg.GetWorkspaceQuota(workspace.Namespace)
+	if err != nil {
+		return "", err
+	}
+	memory, memoryKnown := getMemory(workspace)
+
+	if quota.MaxRunningPerNamespace != nil || quota.MaxMemoryPerNamespace != nil {
+		workspaces := &v1alpha1.WorkspaceList{}
+		if err := reader.List(ctx, workspaces, crclient.InNamespace(workspace.Namespace)); err != nil {
+			return "", err
+		}
+		running, usedMemory := getUsage(workspaces.Items, workspace)
+		if quota.MaxRunningPerNamespace != nil && running >= *quota.MaxRunningPerNamespace {
+			return fmt.Sprintf("namespace '%s' already has %d running workspaces, the maximum is %d",
+				workspace.Namespace, running, *quota.MaxRunningPerNamespace), nil
+		}
+		if quota.MaxMemoryPerNamespace != nil && exceedsMemory(usedMemory, memory, memoryKnown, *quota.MaxMemoryPerNamespace) {
+			subject := fmt.Sprintf("running workspaces in namespace '%s'", workspace.Namespace)
+			return getMemoryMessage(subject, usedMemory, memory, memoryKnown, *quota.MaxMemoryPerNamespace), nil
+		}
+	}
+
+	creator := workspace.Labels[config.WorkspaceCreatorLabel]
+	if creator != "" && (quota.MaxRunningPerUser != nil || quota.MaxMemoryPerUser != nil) {
+		workspaces := &v1alpha1.WorkspaceList{}
+		if err := reader.List(ctx, workspaces, crclient.MatchingLabels{config.WorkspaceCreatorLabel: creator}); err != nil {
+			return "", err
+		}
+		running, usedMemory := getUsage(workspaces.Items, workspace)
+		if quota.MaxRunningPerUser != nil && running >= *quota.MaxRunningPerUser {
+			return fmt.Sprintf("user already has %d running workspaces, the maximum is %d",
+				running, *quota.MaxRunningPerUser), nil
+		}
+		if quota.MaxMemoryPerUser != nil && exceedsMemory(usedMemory, memory, memoryKnown, *quota.MaxMemoryPerUser) {
+			return getMemoryMessage("running workspaces of user", usedMemory, memory, memoryKnown, *quota.MaxMemoryPerUser), nil
+		}
+	}
+	return "", nil
+}
+
+// IsMemoryKnown returns whether the memory of a workspace has been computed, so that memory quotas can be checked
+// exactly
+func IsMemoryKnown(workspace *v1alpha1.Workspace) bool {
+	_, ok := getMemory(workspace)
+	return ok
+}
+
+// getUsage returns the number and the total memory of the admitted workspaces, other than workspace
+func getUsage(workspaces []v1alpha1.Workspace, workspace *v1alpha1.Workspace) (running int, memory resource.Quantity) {
+	for idx := range workspaces {
+		other := &workspaces[idx]
+		if other.Namespace == workspace.Namespace && other.Name == workspace.Name {
+			continue
+		}
+		if !IsAdmitted(other) {
+			continue
+		}
+		running++
+		if otherMemory, ok := getMemory(other); ok {
+			memory.Add(otherMemory)
+		}
+	}
+	return running, memory
+}
+
+// getMemory returns the memory limit of the workspace's containers, or their memory request if limits are not set
+func getMemory(workspace *v1alpha1.Workspace) (memory resource.Quantity, ok bool) {
+	resources := workspace.Status.Resources
+	if resources == nil {
+		return memory, false
+	}
+	if limit, ok := resources.Limits[corev1.ResourceMemory]; ok {
+		return limit, true
+	}
+	if request, ok := resources.Requests[corev1.ResourceMemory]; ok {
+		return request, true
+	}
+	return memory, false
+}
+
+func exceedsMemory(used, memory resource.Quantity, memoryKnown bool, max resource.Quantity) bool {
+	if !memoryKnown {
+		return used.Cmp(max) >= 0
+	}
+	total := used.DeepCopy()
+	total.Add(memory)
+	return total.Cmp(max) > 0
+}
+
+func getMemoryMessage(subject string, used, memory resource.Quantity, memoryKnown bool, max resource.Quantity) string {
+	if !memoryKnown {
+		return fmt.Sprintf("%s already use %s of memory, the maximum is %s", subject, used.String(), max.String())
+	}
+	return fmt.Sprintf("%s use %s of memory, starting a workspace that uses %s would exceed the maximum of %s",
+		subject, used.String(), memory.String(), max.String())
+}
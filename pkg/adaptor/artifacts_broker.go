@@ -21,7 +21,6 @@ import (
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	"github.com/eclipse/che-plugin-broker/model"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,6 +29,7 @@ func getArtifactsBrokerComponent(workspaceId, namespace string, components []v1a
 		configMapVolumeName = "broker-config-volume"
 		configMapMountPath  = "/broker-config"
 		configMapDataName   = "config.json"
+		brokerMemory        = "150Mi"
 	)
 	configMapName := common.PluginBrokerConfigmapName(workspaceId)
 	brokerImage := config.ControllerCfg.GetPluginArtifactsBrokerImage()
@@ -39,6 +39,10 @@ func getArtifactsBrokerComponent(workspaceId, namespace string, components []v1a
 	for _, component := range components {
 		fqns = append(fqns, getPluginFQN(component))
 	}
+	brokerResources, err := adaptResourcesFromString(brokerContainerName, brokerMemory, brokerMemory, "", "")
+	if err != nil {
+		return nil, nil, err
+	}
 	cmData, err := json.Marshal(fqns)
 	if err != nil {
 		return nil, nil, err
@@ -98,14 +102,7 @@ func getArtifactsBrokerComponent(workspaceId, namespace string, components []v1a
 			"--metas",
 			fmt.Sprintf("%s/%s", configMapMountPath, configMapDataName),
 		},
-		Resources: corev1.ResourceRequirements{
-			Limits: corev1.ResourceList{
-				corev1.ResourceMemory: resource.MustParse("150Mi"),
-			},
-			Requests: corev1.ResourceList{
-				corev1.ResourceMemory: resource.MustParse("150Mi"),
-			},
-		},
+		Resources: brokerResources,
 	}
 
 	brokerComponent := &v1alpha1.ComponentDescription{
@@ -16,6 +16,7 @@ import (
 	"fmt"
 
 	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -35,24 +36,194 @@ func SortComponentsByType(components []v1alpha1.ComponentSpec) (dockerimage, plu
 	return
 }
 
-func adaptResourcesFromString(memLimit string) (corev1.ResourceRequirements, error) {
+// adaptResourcesFromString converts the resources specified for a container (e.g. in a devfile or plugin meta.yaml)
+// into resource requirements. Unspecified values are taken from the controller config defaults. Values outside of the
+// configured bounds for workspace containers are rejected with an error naming the component. Requests never exceed
+// limits.
+func adaptResourcesFromString(componentName, memLimit, memRequest, cpuLimit, cpuRequest string) (corev1.ResourceRequirements, error) {
 	if memLimit == "" {
-		memLimit = config.SidecarDefaultMemoryLimit
+		memLimit = config.ControllerCfg.GetSidecarDefaultMemoryLimit()
 	}
-	memLimitQuantity, err := resource.ParseQuantity(memLimit)
-	if err != nil {
-		return corev1.ResourceRequirements{}, err
+	if memRequest == "" {
+		memRequest = config.ControllerCfg.GetSidecarDefaultMemoryRequest()
+	}
+	if cpuLimit == "" {
+		cpuLimit = config.ControllerCfg.GetSidecarDefaultCpuLimit()
 	}
-	resources := corev1.ResourceRequirements{
-		Limits: corev1.ResourceList{
-			corev1.ResourceMemory: memLimitQuantity,
-		},
-		Requests: nil,
+	if cpuRequest == "" {
+		cpuRequest = config.ControllerCfg.GetSidecarDefaultCpuRequest()
 	}
 
+	limits := corev1.ResourceList{}
+	requests := corev1.ResourceList{}
+	if err := addResourceFromString(limits, corev1.ResourceMemory, memLimit); err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid memory limit for component %s: %s", componentName, err)
+	}
+	if err := addResourceFromString(requests, corev1.ResourceMemory, memRequest); err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid memory request for component %s: %s", componentName, err)
+	}
+	if err := addResourceFromString(limits, corev1.ResourceCPU, cpuLimit); err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid cpu limit for component %s: %s", componentName, err)
+	}
+	if err := addResourceFromString(requests, corev1.ResourceCPU, cpuRequest); err != nil {
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid cpu request for component %s: %s", componentName, err)
+	}
+
+	bounds := map[corev1.ResourceName][2]string{
+		corev1.ResourceMemory: {config.ControllerCfg.GetContainerMinMemory(), config.ControllerCfg.GetContainerMaxMemory()},
+		corev1.ResourceCPU:    {config.ControllerCfg.GetContainerMinCpu(), config.ControllerCfg.GetContainerMaxCpu()},
+	}
+	for resourceName, bound := range bounds {
+		if limit, ok := limits[resourceName]; ok {
+			if err := checkResourceBounds(limit, bound[0], bound[1]); err != nil {
+				return corev1.ResourceRequirements{}, fmt.Errorf("invalid %s limit for component %s: %s", resourceName, componentName, err)
+			}
+		}
+		if request, ok := requests[resourceName]; ok {
+			if err := checkResourceBounds(request, bound[0], bound[1]); err != nil {
+				return corev1.ResourceRequirements{}, fmt.Errorf("invalid %s request for component %s: %s", resourceName, componentName, err)
+			}
+		}
+	}
+
+	for resourceName, request := range requests {
+		if limit, ok := limits[resourceName]; ok && request.Cmp(limit) > 0 {
+			requests[resourceName] = limit
+		}
+	}
+
+	resources := corev1.ResourceRequirements{}
+	if len(limits) > 0 {
+		resources.Limits = limits
+	}
+	if len(requests) > 0 {
+		resources.Requests = requests
+	}
 	return resources, nil
 }
 
+func addResourceFromString(resources corev1.ResourceList, resourceName corev1.ResourceName, value string) error {
+	if value == "" {
+		return nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s': %s", value, err)
+	}
+	resources[resourceName] = quantity
+	return nil
+}
+
+// checkResourceBounds returns an error if quantity is less than min or greater than max. Empty bounds are ignored.
+func checkResourceBounds(quantity resource.Quantity, min, max string) error {
+	if min != "" {
+		minQuantity, err := resource.ParseQuantity(min)
+		if err != nil {
+			return fmt.Errorf("failed to parse minimum '%s': %s", min, err)
+		}
+		if quantity.Cmp(minQuantity) < 0 {
+			return fmt.Errorf("%s is less than the minimum of %s", quantity.String(), minQuantity.String())
+		}
+	}
+	if max != "" {
+		maxQuantity, err := resource.ParseQuantity(max)
+		if err != nil {
+			return fmt.Errorf("failed to parse maximum '%s': %s", max, err)
+		}
+		if quantity.Cmp(maxQuantity) > 0 {
+			return fmt.Errorf("%s exceeds the maximum of %s", quantity.String(), maxQuantity.String())
+		}
+	}
+	return nil
+}
+
+// adaptEnvFromDevfile converts devfile environment variables into container environment variables, translating
+// references to Secrets and ConfigMaps into the corresponding valueFrom sources.
+func adaptEnvFromDevfile(devfileEnv []v1alpha1.Env) ([]corev1.EnvVar, error) {
+	var env []corev1.EnvVar
+	for _, devfileEnvVar := range devfileEnv {
+		if devfileEnvVar.ValueFrom == nil {
+			env = append(env, corev1.EnvVar{
+				Name:  devfileEnvVar.Name,
+				Value: devfileEnvVar.Value,
+			})
+			continue
+		}
+		if devfileEnvVar.Value != "" {
+			return nil, fmt.Errorf("environment variable %s cannot specify both value and valueFrom", devfileEnvVar.Name)
+		}
+		secretRef, configMapRef := devfileEnvVar.ValueFrom.SecretKeyRef, devfileEnvVar.ValueFrom.ConfigMapKeyRef
+		envVarSource := &corev1.EnvVarSource{}
+		switch {
+		case secretRef != nil && configMapRef == nil:
+			optional := secretRef.Optional
+			envVarSource.SecretKeyRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretRef.Name},
+				Key:                  secretRef.Key,
+				Optional:             &optional,
+			}
+		case configMapRef != nil && secretRef == nil:
+			optional := configMapRef.Optional
+			envVarSource.ConfigMapKeyRef = &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapRef.Name},
+				Key:                  configMapRef.Key,
+				Optional:             &optional,
+			}
+		default:
+			return nil, fmt.Errorf("valueFrom of environment variable %s must specify exactly one of secretKeyRef or configMapKeyRef", devfileEnvVar.Name)
+		}
+		env = append(env, corev1.EnvVar{
+			Name:      devfileEnvVar.Name,
+			ValueFrom: envVarSource,
+		})
+	}
+	return env, nil
+}
+
+// adaptObjectMountsFromDevfile returns the volumes and volume mounts required to mount the Secrets and ConfigMaps
+// listed in a devfile component. componentName is used to keep volume names unique within the workspace pod.
+func adaptObjectMountsFromDevfile(componentName string, devfileComponent v1alpha1.ComponentSpec) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	for _, secret := range devfileComponent.Secrets {
+		optional := secret.Optional
+		volumeName := common.ObjectMountVolumeName("secret", componentName, secret.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+					Optional:   &optional,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: secret.MountPath,
+			ReadOnly:  true,
+		})
+	}
+	for _, configMap := range devfileComponent.ConfigMaps {
+		optional := configMap.Optional
+		volumeName := common.ObjectMountVolumeName("configmap", componentName, configMap.Name)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+					Optional:             &optional,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: configMap.MountPath,
+			ReadOnly:  true,
+		})
+	}
+	return volumes, volumeMounts
+}
+
 func GetProjectSourcesVolumeMount(workspaceId string) corev1.VolumeMount {
 	volumeName := config.ControllerCfg.GetWorkspacePVCName()
 
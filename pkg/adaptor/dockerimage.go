@@ -14,7 +14,6 @@ package adaptor
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	"github.com/che-incubator/che-workspace-operator/pkg/common"
@@ -22,13 +21,16 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func AdaptDockerimageComponents(workspaceId string, devfileComponents []v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec) ([]v1alpha1.ComponentDescription, error) {
+// AdaptDockerimageComponents converts dockerimage devfile components into ComponentDescriptions. References to
+// environment variables in env values and commands are expanded using the component's env and commonEnv. Commands
+// bound to the postStart and preStop events are run by lifecycle hooks of the containers they target.
+func AdaptDockerimageComponents(workspaceId string, devfileComponents []v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec, events *v1alpha1.DevfileEvents, commonEnv []corev1.EnvVar) ([]v1alpha1.ComponentDescription, error) {
 	var components []v1alpha1.ComponentDescription
 	for _, devfileComponent := range devfileComponents {
 		if devfileComponent.Type != v1alpha1.Dockerimage {
 			return nil, fmt.Errorf("cannot adapt non-dockerfile type component %s in docker adaptor", devfileComponent.Alias)
 		}
-		component, err := adaptDockerimageComponent(workspaceId, devfileComponent, commands)
+		component, err := adaptDockerimageComponent(workspaceId, devfileComponent, commands, events, commonEnv)
 		if err != nil {
 			return nil, err
 		}
@@ -39,20 +41,35 @@ func AdaptDockerimageComponents(workspaceId string, devfileComponents []v1alpha1
 	return components, nil
 }
 
-func adaptDockerimageComponent(workspaceId string, devfileComponent v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec) (v1alpha1.ComponentDescription, error) {
+func adaptDockerimageComponent(workspaceId string, devfileComponent v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec, events *v1alpha1.DevfileEvents, commonEnv []corev1.EnvVar) (v1alpha1.ComponentDescription, error) {
 	container, containerDescription, err := getContainerFromDevfile(workspaceId, devfileComponent)
 	if err != nil {
-		return v1alpha1.ComponentDescription{}, nil
+		return v1alpha1.ComponentDescription{}, err
 	}
 	if devfileComponent.MountSources {
 		container.VolumeMounts = append(container.VolumeMounts, GetProjectSourcesVolumeMount(workspaceId))
 	}
+	volumes, volumeMounts := adaptObjectMountsFromDevfile(devfileComponent.Alias, devfileComponent)
+	container.VolumeMounts = append(container.VolumeMounts, volumeMounts...)
+
+	substitutor := newEnvSubstitutor(container.Env, commonEnv)
+	if err := substitutor.substituteEnv(container.Env); err != nil {
+		return v1alpha1.ComponentDescription{}, fmt.Errorf("failed to process env of component %s: %s", devfileComponent.Alias, err)
+	}
+	componentCommands := GetDockerfileComponentCommands(devfileComponent, commands)
+	if err := substitutor.substituteCommands(componentCommands, false); err != nil {
+		return v1alpha1.ComponentDescription{}, err
+	}
+	container.Lifecycle, err = getContainerLifecycle(devfileComponent, commands, events, substitutor)
+	if err != nil {
+		return v1alpha1.ComponentDescription{}, err
+	}
 
 	componentMetadata := v1alpha1.ComponentMetadata{
 		Containers: map[string]v1alpha1.ContainerDescription{
 			container.Name: containerDescription,
 		},
-		ContributedRuntimeCommands: GetDockerfileComponentCommands(devfileComponent, commands),
+		ContributedRuntimeCommands: componentCommands,
 		Endpoints:                  devfileComponent.Endpoints,
 	}
 
@@ -60,6 +77,7 @@ func adaptDockerimageComponent(workspaceId string, devfileComponent v1alpha1.Com
 		Name: devfileComponent.Alias,
 		PodAdditions: v1alpha1.PodAdditions{
 			Containers: []corev1.Container{container},
+			Volumes:    volumes,
 		},
 		ComponentMetadata: componentMetadata,
 	}
@@ -67,18 +85,15 @@ func adaptDockerimageComponent(workspaceId string, devfileComponent v1alpha1.Com
 }
 
 func getContainerFromDevfile(workspaceId string, devfileComponent v1alpha1.ComponentSpec) (corev1.Container, v1alpha1.ContainerDescription, error) {
-	containerResources, err := adaptResourcesFromString(devfileComponent.MemoryLimit)
+	containerResources, err := adaptResourcesFromString(devfileComponent.Alias, devfileComponent.MemoryLimit, devfileComponent.MemoryRequest, devfileComponent.CpuLimit, devfileComponent.CpuRequest)
 	if err != nil {
 		return corev1.Container{}, v1alpha1.ContainerDescription{}, err
 	}
 	containerEndpoints, endpointInts := endpointsToContainerPorts(devfileComponent.Endpoints)
 
-	var env []corev1.EnvVar
-	for _, devfileEnvVar := range devfileComponent.Env {
-		env = append(env, corev1.EnvVar{
-			Name:  devfileEnvVar.Name,
-			Value: strings.ReplaceAll(devfileEnvVar.Value, "$(CHE_PROJECTS_ROOT)", config.DefaultProjectsSourcesRoot),
-		})
+	env, err := adaptEnvFromDevfile(devfileComponent.Env)
+	if err != nil {
+		return corev1.Container{}, v1alpha1.ContainerDescription{}, err
 	}
 	env = append(env, corev1.EnvVar{
 		Name:  "CHE_MACHINE_NAME",
@@ -138,13 +153,16 @@ func adaptVolumesMountsFromDevfile(workspaceId string, devfileVolumes []v1alpha1
 	return volumeMounts
 }
 
+// GetDockerfileComponentCommands returns a runtime command for each action of the devfile commands that targets a
+// component. Actions of commands with several actions are named using common.CommandActionName; the commands
+// themselves are exposed as composite runtime commands.
 func GetDockerfileComponentCommands(component v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec) []v1alpha1.CheWorkspaceCommand {
 	var componentCommands []v1alpha1.CheWorkspaceCommand
 	for _, command := range commands {
-		for _, action := range command.Actions {
+		for idx, action := range command.Actions {
 			if action.Component == component.Alias {
 				attributes := map[string]string{
-					config.CommandWorkingDirectoryAttribute:       action.Workdir, // TODO: Env var substitution?
+					config.CommandWorkingDirectoryAttribute:       action.Workdir,
 					config.CommandActionReferenceAttribute:        action.Reference,
 					config.CommandActionReferenceContentAttribute: action.ReferenceContent,
 					config.CommandMachineNameAttribute:            component.Alias,
@@ -152,7 +170,7 @@ func GetDockerfileComponentCommands(component v1alpha1.ComponentSpec, commands [
 				}
 
 				componentCommands = append(componentCommands, v1alpha1.CheWorkspaceCommand{
-					Name:        command.Name,
+					Name:        common.CommandActionName(command.Name, idx, len(command.Actions)),
 					Type:        action.Type,
 					CommandLine: action.Command,
 					Attributes:  attributes,
@@ -0,0 +1,140 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package adaptor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const execActionType = "exec"
+
+// getContainerLifecycle returns the lifecycle hooks for the container of a dockerimage component, which run the exec
+// actions of the commands bound to the postStart and preStop events that target the component. References to
+// environment variables in command lines and working directories are expanded by substitutor, as for the component's
+// commands. Returns nil if no such commands exist.
+func getContainerLifecycle(component v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec, events *v1alpha1.DevfileEvents, substitutor *envSubstitutor) (*corev1.Lifecycle, error) {
+	if events == nil {
+		return nil, nil
+	}
+	postStart, err := getLifecycleHandler(component, commands, events.PostStart, substitutor)
+	if err != nil {
+		return nil, err
+	}
+	preStop, err := getLifecycleHandler(component, commands, events.PreStop, substitutor)
+	if err != nil {
+		return nil, err
+	}
+	if postStart == nil && preStop == nil {
+		return nil, nil
+	}
+	return &corev1.Lifecycle{
+		PostStart: postStart,
+		PreStop:   preStop,
+	}, nil
+}
+
+// getLifecycleHandler returns a handler that runs the exec actions of the named commands targeting a component in
+// order, stopping at the first failure. Composite commands are expanded to the actions of the commands they refer to,
+// which are run in order as well, since a hook runs a single process. Command lines are run by a shell in the
+// component's container. As for the component's commands, references to variables that are not known to the
+// substitutor are left as-is, so that e.g. $(date) is a command substitution, and escaped references are unescaped,
+// as Kubernetes does not expand references in lifecycle hooks.
+func getLifecycleHandler(component v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec, commandNames []string, substitutor *envSubstitutor) (*corev1.Handler, error) {
+	script, err := getLifecycleScript(component, commands, commandNames, substitutor, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if len(script) == 0 {
+		return nil, nil
+	}
+	return &corev1.Handler{
+		Exec: &corev1.ExecAction{
+			Command: []string{"/bin/sh", "-c", strings.Join(script, " && ")},
+		},
+	}, nil
+}
+
+// getLifecycleScript returns the shell commands running the exec actions of the named commands that target a
+// component. Visited contains the names of the composite commands that are being expanded, to guard against cycles.
+func getLifecycleScript(component v1alpha1.ComponentSpec, commands []v1alpha1.CommandSpec, commandNames []string, substitutor *envSubstitutor, visited map[string]bool) ([]string, error) {
+	var script []string
+	for _, name := range commandNames {
+		if visited[name] {
+			continue
+		}
+		for _, command := range commands {
+			if command.Name != name {
+				continue
+			}
+			for _, action := range command.Actions {
+				if action.Type != execActionType || action.Component != component.Alias {
+					continue
+				}
+				commandLine, err := substitutor.substitute(action.Command)
+				if err != nil {
+					return nil, fmt.Errorf("failed to process command %s: %s", command.Name, err)
+				}
+				if action.Workdir == "" {
+					script = append(script, fmt.Sprintf("(%s)", unescapeEnvReferences(commandLine)))
+					continue
+				}
+				workdir, err := substitutor.substitute(action.Workdir)
+				if err != nil {
+					return nil, fmt.Errorf("failed to process working directory of command %s: %s", command.Name, err)
+				}
+				script = append(script, fmt.Sprintf("(cd %s && %s)", toShellWord(workdir), unescapeEnvReferences(commandLine)))
+			}
+			if len(command.Commands) > 0 {
+				visited[name] = true
+				nested, err := getLifecycleScript(component, commands, command.Commands, substitutor, visited)
+				delete(visited, name)
+				if err != nil {
+					return nil, err
+				}
+				script = append(script, nested...)
+			}
+		}
+	}
+	return script, nil
+}
+
+// toShellWord quotes a substituted value, e.g. a working directory, so that the shell treats it as a single word.
+// References to unknown variables are left to the shell as command substitutions, as in command lines, while any
+// other text, including escaped references, is taken literally.
+func toShellWord(value string) string {
+	var word strings.Builder
+	literal := func(text string) {
+		if text != "" {
+			word.WriteString("'" + strings.ReplaceAll(text, "'", `'"'"'`) + "'")
+		}
+	}
+	last := 0
+	for _, match := range envReferenceRegexp.FindAllStringIndex(value, -1) {
+		reference := value[match[0]:match[1]]
+		if strings.HasPrefix(reference, "$$") {
+			continue
+		}
+		literal(unescapeEnvReferences(value[last:match[0]]))
+		word.WriteString(`"` + reference + `"`)
+		last = match[1]
+	}
+	literal(unescapeEnvReferences(value[last:]))
+	if word.Len() == 0 {
+		return "''"
+	}
+	return word.String()
+}
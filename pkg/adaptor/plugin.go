@@ -30,12 +30,16 @@ import (
 
 var log = logf.Log.WithName("plugin")
 
-func AdaptPluginComponents(workspaceId, namespace string, devfileComponents []v1alpha1.ComponentSpec) ([]v1alpha1.ComponentDescription, *corev1.ConfigMap, error) {
+// AdaptPluginComponents converts chePlugin and cheEditor devfile components into ComponentDescriptions, using the plugin
+// broker to resolve plugin meta.yamls. References to environment variables in env values and commands are expanded
+// using each container's env and commonEnv. The alias, memoryLimit, env, volumes and endpoints of the devfile components
+// override the settings of the plugins they reference.
+func AdaptPluginComponents(workspaceId, namespace string, devfileComponents []v1alpha1.ComponentSpec, commonEnv []corev1.EnvVar) ([]v1alpha1.ComponentDescription, *corev1.ConfigMap, error) {
 	var components []v1alpha1.ComponentDescription
 
 	broker := metadataBroker.NewBroker(true)
 
-	metas, _, err := getMetasForComponents(devfileComponents)
+	metas, pluginComponents, err := getMetasForComponents(devfileComponents)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -45,16 +49,12 @@ func AdaptPluginComponents(workspaceId, namespace string, devfileComponents []v1
 	}
 
 	for _, plugin := range plugins {
-		component, err := adaptChePluginToComponent(workspaceId, plugin)
+		devfileComponent := pluginComponents[plugin.ID]
+		applyPluginOverrides(&plugin, devfileComponent)
+		component, err := adaptChePluginToComponent(workspaceId, plugin, devfileComponent, commonEnv)
 		if err != nil {
 			return nil, nil, err
 		}
-		// TODO: Alias for plugins seems to be ignored in regular Che
-		// Setting component.Name = alias here breaks matching, as container names do not match alias
-		//if aliases[plugin.ID] != "" {
-		//	component.Name = aliases[plugin.ID]
-		//}
-
 		components = append(components, component)
 	}
 
@@ -71,26 +71,51 @@ func AdaptPluginComponents(workspaceId, namespace string, devfileComponents []v1
 	return components, artifactsBrokerCM, nil
 }
 
-func adaptChePluginToComponent(workspaceId string, plugin brokerModel.ChePlugin) (v1alpha1.ComponentDescription, error) {
+func adaptChePluginToComponent(workspaceId string, plugin brokerModel.ChePlugin, devfileComponent v1alpha1.ComponentSpec, commonEnv []corev1.EnvVar) (v1alpha1.ComponentDescription, error) {
 	var containers []corev1.Container
 	containerDescriptions := map[string]v1alpha1.ContainerDescription{}
+	substitutors := map[string]*envSubstitutor{}
 	for _, pluginContainer := range plugin.Containers {
-		container, containerDescription, err := convertPluginContainer(workspaceId, plugin.ID, pluginContainer)
+		container, containerDescription, err := convertPluginContainer(workspaceId, plugin.ID, pluginContainer, devfileComponent)
 		if err != nil {
 			return v1alpha1.ComponentDescription{}, err
 		}
+		substitutor := newEnvSubstitutor(container.Env, commonEnv)
+		if err := substitutor.substituteEnv(container.Env); err != nil {
+			return v1alpha1.ComponentDescription{}, fmt.Errorf("failed to process env of plugin %s: %s", plugin.ID, err)
+		}
+		substitutors[container.Name] = substitutor
 		containers = append(containers, container)
 		containerDescriptions[container.Name] = containerDescription
 	}
 	var initContainers []corev1.Container
 	for _, pluginInitContainer := range plugin.InitContainers {
-		container, _, err := convertPluginContainer(workspaceId, plugin.ID, pluginInitContainer)
+		container, _, err := convertPluginContainer(workspaceId, plugin.ID, pluginInitContainer, v1alpha1.ComponentSpec{})
 		if err != nil {
 			return v1alpha1.ComponentDescription{}, err
 		}
+		if err := newEnvSubstitutor(container.Env, commonEnv).substituteEnv(container.Env); err != nil {
+			return v1alpha1.ComponentDescription{}, fmt.Errorf("failed to process env of plugin %s: %s", plugin.ID, err)
+		}
 		initContainers = append(initContainers, container)
 	}
 
+	commands := GetPluginComponentCommands(plugin)
+	for idx, command := range commands {
+		substitutor, ok := substitutors[command.Attributes[config.CommandMachineNameAttribute]]
+		if !ok {
+			continue
+		}
+		if err := substitutor.substituteCommands(commands[idx:idx+1], false); err != nil {
+			return v1alpha1.ComponentDescription{}, err
+		}
+	}
+
+	volumes, volumeMounts := adaptObjectMountsFromDevfile(plugin.ID, devfileComponent)
+	for idx := range containers {
+		containers[idx].VolumeMounts = append(containers[idx].VolumeMounts, volumeMounts...)
+	}
+
 	componentName := plugin.Name
 	if len(plugin.Containers) > 0 {
 		componentName = plugin.Containers[0].Name
@@ -100,10 +125,11 @@ func adaptChePluginToComponent(workspaceId string, plugin brokerModel.ChePlugin)
 		PodAdditions: v1alpha1.PodAdditions{
 			Containers:     containers,
 			InitContainers: initContainers,
+			Volumes:        volumes,
 		},
 		ComponentMetadata: v1alpha1.ComponentMetadata{
 			Containers:                 containerDescriptions,
-			ContributedRuntimeCommands: GetPluginComponentCommands(plugin), // TODO: Can regular commands apply to plugins in devfile spec?
+			ContributedRuntimeCommands: commands, // TODO: Can regular commands apply to plugins in devfile spec?
 			Endpoints:                  createEndpointsFromPlugin(plugin),
 		},
 	}
@@ -132,12 +158,81 @@ func createEndpointsFromPlugin(plugin brokerModel.ChePlugin) []v1alpha1.Endpoint
 	return endpoints
 }
 
-func convertPluginContainer(workspaceId, pluginID string, brokerContainer brokerModel.Container) (corev1.Container, v1alpha1.ContainerDescription, error) {
-	memorylimit := brokerContainer.MemoryLimit
-	if memorylimit == "" {
-		memorylimit = config.SidecarDefaultMemoryLimit
+// applyPluginOverrides applies the alias, volumes and endpoints of the devfile component that references a plugin to
+// the plugin resolved by the broker. Containers are renamed using common.PluginContainerName, so that the component is
+// named after the alias in routing and in the workspace runtime. Volumes replace the plugin's volumes with the same
+// name in every container, and are added to every container otherwise. Endpoints replace the plugin's endpoints with
+// the same name, and are added otherwise; the ports of added endpoints are exposed by the plugin's first container. As
+// in the devfile spec and the openshift-oauth routing solver, endpoints without a public attribute are public. Memory
+// limits and env are applied when converting the plugin's containers.
+func applyPluginOverrides(plugin *brokerModel.ChePlugin, devfileComponent v1alpha1.ComponentSpec) {
+	for idx := range plugin.Containers {
+		container := &plugin.Containers[idx]
+		container.Name = common.PluginContainerName(devfileComponent.Alias, container.Name, idx)
+		for _, devfileVolume := range devfileComponent.Volumes {
+			overridden := false
+			for volumeIdx, volume := range container.Volumes {
+				if volume.Name == devfileVolume.Name {
+					container.Volumes[volumeIdx].MountPath = devfileVolume.ContainerPath
+					overridden = true
+				}
+			}
+			if !overridden {
+				container.Volumes = append(container.Volumes, brokerModel.Volume{
+					Name:      devfileVolume.Name,
+					MountPath: devfileVolume.ContainerPath,
+				})
+			}
+		}
 	}
-	containerResources, err := adaptResourcesFromString(memorylimit)
+
+	for _, devfileEndpoint := range devfileComponent.Endpoints {
+		attributes := map[string]string{}
+		for key, val := range devfileEndpoint.Attributes {
+			attributes[string(key)] = val
+		}
+		publicAttr, hasPublicAttr := devfileEndpoint.Attributes[v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE]
+		endpoint := brokerModel.Endpoint{
+			Name:       devfileEndpoint.Name,
+			Public:     !hasPublicAttr || publicAttr == "true",
+			TargetPort: int(devfileEndpoint.Port),
+			Attributes: attributes,
+		}
+		overridden := false
+		for idx, pluginEndpoint := range plugin.Endpoints {
+			if pluginEndpoint.Name == devfileEndpoint.Name {
+				plugin.Endpoints[idx] = endpoint
+				overridden = true
+			}
+		}
+		if !overridden {
+			plugin.Endpoints = append(plugin.Endpoints, endpoint)
+		}
+		if len(plugin.Containers) > 0 && !isPortExposed(plugin.Containers, endpoint.TargetPort) {
+			plugin.Containers[0].Ports = append(plugin.Containers[0].Ports, brokerModel.ExposedPort{ExposedPort: endpoint.TargetPort})
+		}
+	}
+}
+
+func isPortExposed(containers []brokerModel.Container, port int) bool {
+	for _, container := range containers {
+		for _, exposedPort := range container.Ports {
+			if exposedPort.ExposedPort == port {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// convertPluginContainer converts a container from a plugin's meta.yaml into a corev1.Container. Resources and env
+// specified in the devfile component that references the plugin take precedence over those defined in the meta.yaml.
+func convertPluginContainer(workspaceId, pluginID string, brokerContainer brokerModel.Container, devfileComponent v1alpha1.ComponentSpec) (corev1.Container, v1alpha1.ContainerDescription, error) {
+	memoryLimit := brokerContainer.MemoryLimit
+	if devfileComponent.MemoryLimit != "" {
+		memoryLimit = devfileComponent.MemoryLimit
+	}
+	containerResources, err := adaptResourcesFromString(brokerContainer.Name, memoryLimit, devfileComponent.MemoryRequest, devfileComponent.CpuLimit, devfileComponent.CpuRequest)
 	if err != nil {
 		return corev1.Container{}, v1alpha1.ContainerDescription{}, err
 	}
@@ -146,9 +241,25 @@ func convertPluginContainer(workspaceId, pluginID string, brokerContainer broker
 	for _, brokerEnv := range brokerContainer.Env {
 		env = append(env, corev1.EnvVar{
 			Name:  brokerEnv.Name,
-			Value: strings.ReplaceAll(brokerEnv.Value, "$(CHE_PROJECTS_ROOT)", config.DefaultProjectsSourcesRoot),
+			Value: brokerEnv.Value,
 		})
 	}
+	devfileEnv, err := adaptEnvFromDevfile(devfileComponent.Env)
+	if err != nil {
+		return corev1.Container{}, v1alpha1.ContainerDescription{}, err
+	}
+	for _, devfileEnvVar := range devfileEnv {
+		overridden := false
+		for idx := range env {
+			if env[idx].Name == devfileEnvVar.Name {
+				env[idx] = devfileEnvVar
+				overridden = true
+			}
+		}
+		if !overridden {
+			env = append(env, devfileEnvVar)
+		}
+	}
 
 	var containerPorts []corev1.ContainerPort
 	var portInts []int
@@ -202,10 +313,10 @@ func adaptVolumeMountsFromBroker(workspaceId string, brokerContainer brokerModel
 	return volumeMounts
 }
 
-func getMetasForComponents(components []v1alpha1.ComponentSpec) (metas []brokerModel.PluginMeta, aliases map[string]string, err error) {
+func getMetasForComponents(components []v1alpha1.ComponentSpec) (metas []brokerModel.PluginMeta, pluginComponents map[string]v1alpha1.ComponentSpec, err error) {
 	defaultRegistry := config.ControllerCfg.GetPluginRegistry()
 	ioUtils := utils.New()
-	aliases = map[string]string{}
+	pluginComponents = map[string]v1alpha1.ComponentSpec{}
 	for _, component := range components {
 		if component.Type != v1alpha1.ChePlugin && component.Type != v1alpha1.CheEditor {
 			return nil, nil, fmt.Errorf("cannot adapt non-plugin or editor type component %s in plugin adaptor", component.Type)
@@ -225,13 +336,13 @@ func getMetasForComponents(components []v1alpha1.ComponentSpec) (metas []brokerM
 			return nil, nil, err
 		}
 		metas = append(metas, *meta)
-		aliases[meta.ID] = component.Alias
+		pluginComponents[meta.ID] = component
 	}
 	err = utils.ResolveRelativeExtensionPaths(metas, defaultRegistry)
 	if err != nil {
 		return nil, nil, err
 	}
-	return metas, aliases, nil
+	return metas, pluginComponents, nil
 }
 
 func getPluginFQN(component v1alpha1.ComponentSpec) brokerModel.PluginFQN {
@@ -257,7 +368,7 @@ func GetPluginComponentCommands(plugin brokerModel.ChePlugin) []v1alpha1.CheWork
 				CommandLine: strings.Join(pluginCommand.Command, " "),
 				Type:        "custom",
 				Attributes: map[string]string{
-					config.CommandWorkingDirectoryAttribute: pluginCommand.WorkingDir, // TODO: Env Var substitution?
+					config.CommandWorkingDirectoryAttribute: pluginCommand.WorkingDir,
 					config.CommandMachineNameAttribute:      pluginContainer.Name,
 				},
 			}
@@ -0,0 +1,180 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package adaptor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// envReferenceRegexp matches references to environment variables of the form $(VAR_NAME). As in Kubernetes, a
+// reference can be escaped by doubling the dollar sign, i.e. $$(VAR_NAME).
+var envReferenceRegexp = regexp.MustCompile(`\$?\$\(([^()$]+)\)`)
+
+// envSubstitutor expands references to environment variables in strings. Variable values may themselves contain
+// references, which are expanded recursively. References to unknown variables and escaped references are left as-is,
+// so that they can be resolved later (e.g. endpoint URLs, once routing is ready) or by Kubernetes.
+type envSubstitutor struct {
+	vars     map[string]string
+	resolved map[string]string
+}
+
+// newEnvSubstitutor creates an envSubstitutor for the variables in envs. If a variable is defined more than once, the
+// first definition is used, so that a component's own variables take precedence over common ones.
+func newEnvSubstitutor(envs ...[]corev1.EnvVar) *envSubstitutor {
+	vars := map[string]string{}
+	for _, envList := range envs {
+		for _, envVar := range envList {
+			if _, exists := vars[envVar.Name]; !exists && envVar.ValueFrom == nil {
+				vars[envVar.Name] = envVar.Value
+			}
+		}
+	}
+	return &envSubstitutor{
+		vars:     vars,
+		resolved: map[string]string{},
+	}
+}
+
+func (s *envSubstitutor) substitute(value string) (string, error) {
+	return s.expand(value, nil)
+}
+
+func (s *envSubstitutor) substituteEnv(env []corev1.EnvVar) error {
+	for idx, envVar := range env {
+		if envVar.ValueFrom != nil {
+			continue
+		}
+		value, err := s.substitute(envVar.Value)
+		if err != nil {
+			return err
+		}
+		env[idx].Value = value
+	}
+	return nil
+}
+
+// substituteCommands expands references in the command lines and working directories of commands. If unescape is
+// true, escaped references are unescaped, as no further substitution will happen for commands.
+func (s *envSubstitutor) substituteCommands(commands []v1alpha1.CheWorkspaceCommand, unescape bool) error {
+	for idx, command := range commands {
+		commandLine, err := s.substitute(command.CommandLine)
+		if err != nil {
+			return fmt.Errorf("failed to process command %s: %s", command.Name, err)
+		}
+		if unescape {
+			commandLine = unescapeEnvReferences(commandLine)
+		}
+		commands[idx].CommandLine = commandLine
+
+		if workdir, ok := command.Attributes[config.CommandWorkingDirectoryAttribute]; ok {
+			workdir, err = s.substitute(workdir)
+			if err != nil {
+				return fmt.Errorf("failed to process working directory of command %s: %s", command.Name, err)
+			}
+			if unescape {
+				workdir = unescapeEnvReferences(workdir)
+			}
+			commands[idx].Attributes[config.CommandWorkingDirectoryAttribute] = workdir
+		}
+	}
+	return nil
+}
+
+func (s *envSubstitutor) expand(value string, stack []string) (string, error) {
+	var err error
+	expanded := envReferenceRegexp.ReplaceAllStringFunc(value, func(reference string) string {
+		if err != nil || strings.HasPrefix(reference, "$$") {
+			return reference
+		}
+		name := envReferenceRegexp.FindStringSubmatch(reference)[1]
+		resolved, ok, resolveErr := s.resolve(name, stack)
+		if resolveErr != nil {
+			err = resolveErr
+			return reference
+		}
+		if !ok {
+			return reference
+		}
+		return resolved
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+func (s *envSubstitutor) resolve(name string, stack []string) (value string, ok bool, err error) {
+	if resolved, ok := s.resolved[name]; ok {
+		return resolved, true, nil
+	}
+	raw, ok := s.vars[name]
+	if !ok {
+		return "", false, nil
+	}
+	for _, resolving := range stack {
+		if resolving == name {
+			return "", false, fmt.Errorf("cyclic reference in environment variables: %s", strings.Join(append(stack, name), " -> "))
+		}
+	}
+	value, err = s.expand(raw, append(stack, name))
+	if err != nil {
+		return "", false, err
+	}
+	s.resolved[name] = value
+	return value, true, nil
+}
+
+func unescapeEnvReferences(value string) string {
+	return strings.ReplaceAll(value, "$$(", "$(")
+}
+
+// SubstituteEndpointURLs expands references to workspace endpoint URLs in the environment variables and commands of
+// components. An endpoint's URL is referenced by the variable named by common.EndpointURLEnvVarName, e.g.
+// $(CHE_ENDPOINT_THEIA_URL). As this is the last substitution applied to commands, escaped references in commands are
+// unescaped as well.
+func SubstituteEndpointURLs(components []v1alpha1.ComponentDescription, exposedEndpoints map[string]v1alpha1.ExposedEndpointList) error {
+	var endpointEnv []corev1.EnvVar
+	for _, endpoints := range exposedEndpoints {
+		for _, endpoint := range endpoints {
+			endpointEnv = append(endpointEnv, corev1.EnvVar{
+				Name:  common.EndpointURLEnvVarName(endpoint.Name),
+				Value: endpoint.Url,
+			})
+		}
+	}
+	substitutor := newEnvSubstitutor(endpointEnv)
+
+	for _, component := range components {
+		for _, container := range component.PodAdditions.Containers {
+			if err := substitutor.substituteEnv(container.Env); err != nil {
+				return err
+			}
+		}
+		for _, container := range component.PodAdditions.InitContainers {
+			if err := substitutor.substituteEnv(container.Env); err != nil {
+				return err
+			}
+		}
+		if err := substitutor.substituteCommands(component.ComponentMetadata.ContributedRuntimeCommands, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
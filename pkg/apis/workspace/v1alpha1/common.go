@@ -46,6 +46,9 @@ type PodAdditions struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
+	// EnvFrom sources to add to all containers in a workspace deployment
+	// +optional
+	EnvFrom []v1.EnvFromSource `json:"envFrom,omitempty"`
 	// ImagePullSecrets to add to workspace deployment
 	// +optional
 	// +patchMergeKey=name
@@ -52,4 +52,8 @@ type CheWorkspaceCommand struct {
 	CommandLine string `json:"commandLine"`
 	// Attributes for command
 	Attributes map[string]string `json:"attributes,omitempty"`
+	// Names of the commands run by a composite command. Set only for commands of type composite.
+	Commands []string `json:"commands,omitempty"`
+	// Whether the commands of a composite command are run in parallel rather than in order
+	Parallel bool `json:"parallel,omitempty"`
 }
@@ -25,6 +25,8 @@ type WorkspaceComponentSpec struct {
 	Components []ComponentSpec `json:"components"`
 	// Commands from devfile, to be matched to components
 	Commands []CommandSpec `json:"commands,omitempty"`
+	// Bindings of commands from devfile to events in the workspace lifecycle
+	Events *DevfileEvents `json:"events,omitempty"`
 }
 
 // ComponentStatus defines the observed state of Component
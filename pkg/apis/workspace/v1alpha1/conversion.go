@@ -0,0 +1,17 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha1
+
+// Hub marks v1alpha1 as the version all other versions of the Workspace API are converted to and from. It is the
+// version the controllers work with.
+func (*Workspace) Hub() {}
@@ -31,6 +31,9 @@ type DevfileSpec struct {
 
 	// List of workspace-wide commands that can be associated to a given component, in order to run in the related container
 	Commands []CommandSpec `json:"commands,omitempty" yaml:"commands,omitempty"` // Description of the predefined commands to be available in workspace
+
+	// Bindings of commands to events in the workspace lifecycle
+	Events *DevfileEvents `json:"events,omitempty" yaml:"events,omitempty"`
 }
 
 type DevfileMeta struct {
@@ -44,14 +47,16 @@ type DevfileAttributes struct {
 }
 
 type ProjectSpec struct {
-	Name   string            `json:"name" yaml:"name"`
-	Source ProjectSourceSpec `json:"source" yaml:"source"` // Describes the project's source - type and location
+	Name      string            `json:"name" yaml:"name"`
+	Source    ProjectSourceSpec `json:"source" yaml:"source"`                           // Describes the project's source - type and location
+	ClonePath string            `json:"clonePath,omitempty" yaml:"clonePath,omitempty"` // Path relative to the projects root where the project should be cloned. Defaults to the project name
 }
 
 // Describes the project's source - type and location
 type ProjectSourceSpec struct {
-	Location string `json:"location" yaml:"location"` // Project's source location address. Should be URL for git and github located projects, or; file:// for zip.
-	Type     string `json:"type" yaml:"type"`         // Project's source type.
+	Location string `json:"location" yaml:"location"`                 // Project's source location address. Should be URL for git and github located projects, or; file:// for zip.
+	Type     string `json:"type" yaml:"type"`                         // Project's source type.
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"` // The branch to check out. Applicable only to git and github sources
 }
 
 type ComponentSpec struct {
@@ -70,14 +75,19 @@ type ComponentSpec struct {
 
 	//fields for dockerimage type
 
-	Image        string     `json:"image,omitempty" yaml:"image,omitempty"`               // Specifies the docker image that should be used for component
-	MemoryLimit  string     `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`   // Describes memory limit for the component. You can express memory as a plain integer or as a; fixed-point integer using one of these suffixes: E, P, T, G, M, K. You can also use the; power-of-two equivalents: Ei, Pi, Ti, Gi, Mi, Ki
-	MountSources bool       `json:"mountSources,omitempty" yaml:"mountSources,omitempty"` // Describes whether projects sources should be mount to the component. `CHE_PROJECTS_ROOT`; environment variable should contains a path where projects sources are mount
-	Endpoints    []Endpoint `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`       // Describes dockerimage component endpoints
-	Env          []Env      `json:"env,omitempty" yaml:"env,omitempty"`                   // The environment variables list that should be set to docker container
-	Volumes      []Volume   `json:"volumes,omitempty" yaml:"volumes,omitempty"`           // Describes volumes which should be mount to component
-	Command      []string   `json:"command,omitempty" yaml:"command,omitempty"`           // The command to run in the dockerimage component instead of the default one provided in the image. Defaults to null, meaning use whatever is defined in the image.
-	Args         []string   `json:"args,omitempty" yaml:"args,omitempty"`                 // The arguments to supply to the command running the dockerimage component. The arguments are supplied either to the default command provided in the image or to the overridden command. Defaults to null, meaning use whatever is defined in the image.
+	Image         string        `json:"image,omitempty" yaml:"image,omitempty"`                 // Specifies the docker image that should be used for component
+	MemoryLimit   string        `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`     // Describes memory limit for the component. You can express memory as a plain integer or as a; fixed-point integer using one of these suffixes: E, P, T, G, M, K. You can also use the; power-of-two equivalents: Ei, Pi, Ti, Gi, Mi, Ki
+	MemoryRequest string        `json:"memoryRequest,omitempty" yaml:"memoryRequest,omitempty"` // Describes memory request for the component. Uses the same format as memoryLimit and must not exceed it
+	CpuLimit      string        `json:"cpuLimit,omitempty" yaml:"cpuLimit,omitempty"`           // Describes CPU limit for the component. You can express CPU as a plain number of cores (e.g. 0.5) or; in millicores using the m suffix (e.g. 500m)
+	CpuRequest    string        `json:"cpuRequest,omitempty" yaml:"cpuRequest,omitempty"`       // Describes CPU request for the component. Uses the same format as cpuLimit and must not exceed it
+	MountSources  bool          `json:"mountSources,omitempty" yaml:"mountSources,omitempty"`   // Describes whether projects sources should be mount to the component. `CHE_PROJECTS_ROOT`; environment variable should contains a path where projects sources are mount
+	Endpoints     []Endpoint    `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`         // Describes dockerimage component endpoints. For cheEditor and chePlugin components, overrides the plugin's endpoints with the same name
+	Env           []Env         `json:"env,omitempty" yaml:"env,omitempty"`                     // The environment variables list that should be set to docker container. For cheEditor and chePlugin components, set in all plugin containers
+	Volumes       []Volume      `json:"volumes,omitempty" yaml:"volumes,omitempty"`             // Describes volumes which should be mount to component. For cheEditor and chePlugin components, overrides the plugin's volumes with the same name
+	Secrets       []ObjectMount `json:"secrets,omitempty" yaml:"secrets,omitempty"`             // Describes Secrets which should be mounted as files to component
+	ConfigMaps    []ObjectMount `json:"configMaps,omitempty" yaml:"configMaps,omitempty"`       // Describes ConfigMaps which should be mounted as files to component
+	Command       []string      `json:"command,omitempty" yaml:"command,omitempty"`             // The command to run in the dockerimage component instead of the default one provided in the image. Defaults to null, meaning use whatever is defined in the image.
+	Args          []string      `json:"args,omitempty" yaml:"args,omitempty"`                   // The arguments to supply to the command running the dockerimage component. The arguments are supplied either to the default command provided in the image or to the overridden command. Defaults to null, meaning use whatever is defined in the image.
 
 	//provision fields for kubernetes&openshift types
 
@@ -112,12 +122,37 @@ const (
 	PATH_ENDPOINT_ATTRIBUTE EndpointAttribute = "path"
 
 	DISCOVERABLE_ATTRIBUTE EndpointAttribute = "discoverable"
+
+	//endpoint attribute that is used to configure whether a discoverable endpoint may be accessed by other workspaces
+	//in the same namespace
+	SHARED_ENDPOINT_ATTRIBUTE EndpointAttribute = "shared"
 )
 
 // Describes environment variable
 type Env struct {
-	Name  string `json:"name" yaml:"name"`   // The environment variable name
-	Value string `json:"value" yaml:"value"` // The environment variable value
+	Name      string        `json:"name" yaml:"name"`                               // The environment variable name
+	Value     string        `json:"value,omitempty" yaml:"value,omitempty"`         // The environment variable value
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty" yaml:"valueFrom,omitempty"` // Source for the environment variable's value. Cannot be used if value is not empty
+}
+
+// Describes a source for the value of an environment variable
+type EnvVarSource struct {
+	SecretKeyRef    *KeySelector `json:"secretKeyRef,omitempty" yaml:"secretKeyRef,omitempty"`       // Selects a key of a Secret in the workspace's namespace
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty" yaml:"configMapKeyRef,omitempty"` // Selects a key of a ConfigMap in the workspace's namespace
+}
+
+// Selects a key of a Secret or ConfigMap
+type KeySelector struct {
+	Name     string `json:"name" yaml:"name"`                             // The name of the Secret or ConfigMap
+	Key      string `json:"key" yaml:"key"`                               // The key to select
+	Optional bool   `json:"optional,omitempty" yaml:"optional,omitempty"` // Whether the Secret or ConfigMap and its key may be absent
+}
+
+// Describes a Secret or ConfigMap that should be mounted as files into a component
+type ObjectMount struct {
+	Name      string `json:"name" yaml:"name"`                             // The name of the Secret or ConfigMap in the workspace's namespace
+	MountPath string `json:"mountPath" yaml:"mountPath"`                   // The path where the Secret or ConfigMap should be mounted; each key is mounted as a file in this directory
+	Optional  bool   `json:"optional,omitempty" yaml:"optional,omitempty"` // Whether the Secret or ConfigMap may be absent
 }
 
 // Describe volume that should be mount to component
@@ -137,7 +172,9 @@ const (
 )
 
 type CommandSpec struct {
-	Actions    []CommandActionSpec `json:"actions,omitempty" yaml:"actions,omitempty"`       // List of the actions of given command. Now the only one command must be specified in list; but there are plans to implement supporting multiple actions commands.
+	Actions    []CommandActionSpec `json:"actions,omitempty" yaml:"actions,omitempty"`       // List of the actions of given command. Actions are run in order, unless parallel is set.
+	Commands   []string            `json:"commands,omitempty" yaml:"commands,omitempty"`     // Names of the commands run by a composite command. A command has either actions or commands.
+	Parallel   bool                `json:"parallel,omitempty" yaml:"parallel,omitempty"`     // Whether the actions or commands of given command are run in parallel rather than in order
 	Attributes map[string]string   `json:"attributes,omitempty" yaml:"attributes,omitempty"` // Additional command attributes
 	Name       string              `json:"name" yaml:"name"`                                 // Describes the name of the command. Should be unique per commands set.
 }
@@ -0,0 +1,179 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha1
+
+// This schema describes the structure of a devfile 2.0 object
+type DevfileV2Spec struct {
+	// Devfile schema version
+	SchemaVersion string `json:"schemaVersion" yaml:"schemaVersion"`
+
+	// Devfile metadata
+	Metadata DevfileMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Parent devfile that this devfile inherits from. Components, commands and projects of this devfile override the
+	// parent's ones with the same name, and are added otherwise.
+	Parent *DevfileV2Parent `json:"parent,omitempty" yaml:"parent,omitempty"`
+
+	// List of projects that should be imported into the workspace
+	Projects []DevfileV2Project `json:"projects,omitempty" yaml:"projects,omitempty"`
+
+	// List of components (containers, volumes, plugins, ...) that will provide the workspace features
+	Components []DevfileV2Component `json:"components,omitempty" yaml:"components,omitempty"`
+
+	// List of workspace-wide commands
+	Commands []DevfileV2Command `json:"commands,omitempty" yaml:"commands,omitempty"`
+
+	// Bindings of commands to events in the workspace lifecycle
+	Events *DevfileEvents `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// Describes the parent of a devfile. Exactly one of the location fields (Uri, Id or Kubernetes) should be set.
+type DevfileV2Parent struct {
+	Uri         string               `json:"uri,omitempty" yaml:"uri,omitempty"`                 // URI of the parent devfile
+	Id          string               `json:"id,omitempty" yaml:"id,omitempty"`                   // Id of the parent devfile in a registry
+	RegistryUrl string               `json:"registryUrl,omitempty" yaml:"registryUrl,omitempty"` // Registry to look up the parent devfile id in
+	Kubernetes  *KubernetesReference `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"`   // Reference to a Kubernetes object holding the parent devfile
+	Components  []DevfileV2Component `json:"components,omitempty" yaml:"components,omitempty"`   // Overrides of the parent's components
+	Commands    []DevfileV2Command   `json:"commands,omitempty" yaml:"commands,omitempty"`       // Overrides of the parent's commands
+	Projects    []DevfileV2Project   `json:"projects,omitempty" yaml:"projects,omitempty"`       // Overrides of the parent's projects
+}
+
+// Reference to a namespaced Kubernetes object
+type KubernetesReference struct {
+	Name      string `json:"name" yaml:"name"`                               // The name of the object
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"` // The namespace of the object. Defaults to the workspace's namespace
+}
+
+// Describes a project of a devfile 2.0. Exactly one of the source fields (Git, Github or Zip) should be set.
+type DevfileV2Project struct {
+	Name      string                  `json:"name" yaml:"name"`                               // The project name
+	ClonePath string                  `json:"clonePath,omitempty" yaml:"clonePath,omitempty"` // Path relative to the projects root where the project should be cloned. Defaults to the project name
+	Git       *DevfileV2ProjectSource `json:"git,omitempty" yaml:"git,omitempty"`             // Project's git source
+	Github    *DevfileV2ProjectSource `json:"github,omitempty" yaml:"github,omitempty"`       // Project's github source
+	Zip       *DevfileV2ProjectSource `json:"zip,omitempty" yaml:"zip,omitempty"`             // Project's zip source
+}
+
+type DevfileV2ProjectSource struct {
+	Location string `json:"location" yaml:"location"`                 // Project's source location address
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"` // The branch to check out. Applicable only to git and github sources
+}
+
+// Describes a component of a devfile 2.0. Exactly one of the component type fields should be set.
+type DevfileV2Component struct {
+	Name       string                        `json:"name" yaml:"name"`                                 // The component name. Should be unique per component set
+	Container  *DevfileV2ContainerComponent  `json:"container,omitempty" yaml:"container,omitempty"`   // A container that is part of the workspace pod
+	Volume     *DevfileV2VolumeComponent     `json:"volume,omitempty" yaml:"volume,omitempty"`         // A volume that can be mounted into containers
+	Kubernetes *DevfileV2KubernetesComponent `json:"kubernetes,omitempty" yaml:"kubernetes,omitempty"` // Kubernetes objects that are part of the workspace
+	Openshift  *DevfileV2KubernetesComponent `json:"openshift,omitempty" yaml:"openshift,omitempty"`   // OpenShift objects that are part of the workspace
+	Plugin     *DevfileV2PluginComponent     `json:"plugin,omitempty" yaml:"plugin,omitempty"`         // A Che plugin or editor
+}
+
+type DevfileV2ContainerComponent struct {
+	Image         string                 `json:"image" yaml:"image"`                                     // Specifies the docker image that should be used for component
+	MemoryLimit   string                 `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`     // Describes memory limit for the component
+	MemoryRequest string                 `json:"memoryRequest,omitempty" yaml:"memoryRequest,omitempty"` // Describes memory request for the component
+	CpuLimit      string                 `json:"cpuLimit,omitempty" yaml:"cpuLimit,omitempty"`           // Describes CPU limit for the component
+	CpuRequest    string                 `json:"cpuRequest,omitempty" yaml:"cpuRequest,omitempty"`       // Describes CPU request for the component
+	MountSources  *bool                  `json:"mountSources,omitempty" yaml:"mountSources,omitempty"`   // Whether projects sources should be mounted to the component. Defaults to true
+	Endpoints     []DevfileV2Endpoint    `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`         // Describes container endpoints
+	Env           []Env                  `json:"env,omitempty" yaml:"env,omitempty"`                     // The environment variables list that should be set to the container
+	VolumeMounts  []DevfileV2VolumeMount `json:"volumeMounts,omitempty" yaml:"volumeMounts,omitempty"`   // Describes volume components which should be mounted to the container
+	Command       []string               `json:"command,omitempty" yaml:"command,omitempty"`             // The command to run in the container instead of the default one provided in the image
+	Args          []string               `json:"args,omitempty" yaml:"args,omitempty"`                   // The arguments to supply to the command running in the container
+}
+
+type DevfileV2VolumeMount struct {
+	Name string `json:"name" yaml:"name"` // The name of the volume component to mount
+	Path string `json:"path" yaml:"path"` // The path in the container where the volume should be mounted
+}
+
+type DevfileV2VolumeComponent struct {
+	Size string `json:"size,omitempty" yaml:"size,omitempty"` // Size of the volume. Ignored, as all workspace volumes are stored in the common workspace PVC
+}
+
+type DevfileV2KubernetesComponent struct {
+	Uri     string `json:"uri,omitempty" yaml:"uri,omitempty"`         // Location of the Kubernetes list yaml file
+	Inlined string `json:"inlined,omitempty" yaml:"inlined,omitempty"` // Inlined content of the Kubernetes list yaml file
+}
+
+type DevfileV2PluginComponent struct {
+	Id          string `json:"id" yaml:"id"`                                       // The plugin id, e.g. eclipse/che-theia/next
+	RegistryUrl string `json:"registryUrl,omitempty" yaml:"registryUrl,omitempty"` // Registry to look up the plugin id in
+	MemoryLimit string `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"` // Describes memory limit for the plugin's containers
+}
+
+// Describes an endpoint of a devfile 2.0 container
+type DevfileV2Endpoint struct {
+	Name       string            `json:"name" yaml:"name"`                                 // The endpoint name
+	TargetPort int64             `json:"targetPort" yaml:"targetPort"`                     // The port the endpoint listens on
+	Exposure   EndpointExposure  `json:"exposure,omitempty" yaml:"exposure,omitempty"`     // Describes how the endpoint is exposed. Defaults to public
+	Protocol   string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`     // The protocol used by the endpoint, e.g. http or ws. Defaults to http
+	Secure     bool              `json:"secure,omitempty" yaml:"secure,omitempty"`         // Whether the endpoint should be covered with authentication
+	Path       string            `json:"path,omitempty" yaml:"path,omitempty"`             // Path that should be used by default to access the endpoint
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"` // Additional endpoint attributes, e.g. type
+}
+
+type EndpointExposure string
+
+const (
+	// Endpoint is exposed outside of the cluster
+	PublicEndpointExposure EndpointExposure = "public"
+	// Endpoint is only accessible from within the cluster
+	InternalEndpointExposure EndpointExposure = "internal"
+	// Endpoint is only accessible from within the workspace
+	NoneEndpointExposure EndpointExposure = "none"
+)
+
+// Describes a command of a devfile 2.0. Exactly one of the command type fields should be set.
+type DevfileV2Command struct {
+	Id        string                     `json:"id" yaml:"id"`                                   // The command id. Should be unique per command set
+	Exec      *DevfileV2ExecCommand      `json:"exec,omitempty" yaml:"exec,omitempty"`           // A command line executed in a container component
+	Apply     *DevfileV2ApplyCommand     `json:"apply,omitempty" yaml:"apply,omitempty"`         // Applies a component, e.g. runs an init container for a container component
+	Composite *DevfileV2CompositeCommand `json:"composite,omitempty" yaml:"composite,omitempty"` // Runs several other commands
+}
+
+type DevfileV2ExecCommand struct {
+	Label       string                 `json:"label,omitempty" yaml:"label,omitempty"`           // Human-readable name of the command. Defaults to the command id
+	Component   string                 `json:"component" yaml:"component"`                       // The container component the command runs in
+	CommandLine string                 `json:"commandLine" yaml:"commandLine"`                   // The actual command-line string
+	WorkingDir  string                 `json:"workingDir,omitempty" yaml:"workingDir,omitempty"` // Working directory where the command should be executed
+	Group       *DevfileV2CommandGroup `json:"group,omitempty" yaml:"group,omitempty"`           // The group the command belongs to
+}
+
+type DevfileV2ApplyCommand struct {
+	Label     string                 `json:"label,omitempty" yaml:"label,omitempty"` // Human-readable name of the command. Defaults to the command id
+	Component string                 `json:"component" yaml:"component"`             // The component that is applied
+	Group     *DevfileV2CommandGroup `json:"group,omitempty" yaml:"group,omitempty"` // The group the command belongs to
+}
+
+type DevfileV2CompositeCommand struct {
+	Label    string                 `json:"label,omitempty" yaml:"label,omitempty"`       // Human-readable name of the command. Defaults to the command id
+	Commands []string               `json:"commands" yaml:"commands"`                     // Ids of the commands to run
+	Parallel bool                   `json:"parallel,omitempty" yaml:"parallel,omitempty"` // Whether the commands should be run in parallel
+	Group    *DevfileV2CommandGroup `json:"group,omitempty" yaml:"group,omitempty"`       // The group the command belongs to
+}
+
+type DevfileV2CommandGroup struct {
+	Kind      string `json:"kind" yaml:"kind"`                               // The kind of the group, e.g. build, run, test or debug
+	IsDefault bool   `json:"isDefault,omitempty" yaml:"isDefault,omitempty"` // Whether the command is the default one for its group
+}
+
+// Bindings of commands to events in the workspace lifecycle. Each list contains command ids (for devfile 2.0) or names
+// (for devfile 1.0). Only exec commands targeting dockerimage (container) components can be bound to postStart and
+// preStop; preStart and postStop are not supported yet and are ignored.
+type DevfileEvents struct {
+	PreStart  []string `json:"preStart,omitempty" yaml:"preStart,omitempty"`   // Commands run before the workspace's containers are started
+	PostStart []string `json:"postStart,omitempty" yaml:"postStart,omitempty"` // Commands run after the workspace's containers are started
+	PreStop   []string `json:"preStop,omitempty" yaml:"preStop,omitempty"`     // Commands run before the workspace's containers are stopped
+	PostStop  []string `json:"postStop,omitempty" yaml:"postStop,omitempty"`   // Commands run after the workspace's containers are stopped
+}
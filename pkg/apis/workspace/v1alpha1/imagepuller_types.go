@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImagePullerSpec defines images cached on every node in addition to those collected by the controller
+// +k8s:openapi-gen=true
+type ImagePullerSpec struct {
+	// Additional images to cache on every node
+	Images []string `json:"images,omitempty"`
+}
+
+// ImagePullerStatus defines the observed state of the image puller
+// +k8s:openapi-gen=true
+type ImagePullerStatus struct {
+	// Images cached by the image puller
+	Images []ImagePullerImageStatus `json:"images,omitempty"`
+	// Number of nodes that should run the image puller
+	DesiredNodes int32 `json:"desiredNodes"`
+	// Number of nodes where all images are cached
+	ReadyNodes int32 `json:"readyNodes"`
+}
+
+// ImagePullerImageStatus describes the nodes an image is cached on
+type ImagePullerImageStatus struct {
+	// Name of the image
+	Image string `json:"image"`
+	// Number of nodes the image is cached on
+	Nodes int32 `json:"nodes"`
+	// Human-readable message indicating why the image could not be pulled on some nodes
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImagePuller is the Schema for the imagepullers API. The controller manages a single ImagePuller in its namespace
+// when the image puller is enabled in the controller config.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=imagepullers,scope=Namespaced
+// +kubebuilder:printcolumn:name="Desired Nodes",type="integer",JSONPath=".status.desiredNodes",description="The number of nodes that should cache images"
+// +kubebuilder:printcolumn:name="Ready Nodes",type="integer",JSONPath=".status.readyNodes",description="The number of nodes where all images are cached"
+type ImagePuller struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImagePullerSpec   `json:"spec,omitempty"`
+	Status ImagePullerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ImagePullerList contains a list of ImagePuller
+type ImagePullerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImagePuller `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ImagePuller{}, &ImagePullerList{})
+}
@@ -26,9 +26,62 @@ type WorkspaceSpec struct {
 	RoutingClass WorkspaceRoutingClass `json:"routingClass,omitempty"`
 	// Workspace Structure defined in the Devfile format syntax.
 	// For more details see the Che 7 documentation: https://www.eclipse.org/che/docs/che-7/making-a-workspace-portable-using-a-devfile/
-	Devfile DevfileSpec `json:"devfile"`
+	// Exactly one of devfile and devfileV2 must be set, unless the workspace uses a template.
+	Devfile *DevfileSpec `json:"devfile,omitempty"`
+	// Workspace Structure defined in the Devfile 2.0 format syntax. Exactly one of devfile and devfileV2 must be set,
+	// unless the workspace uses a template.
+	DevfileV2 *DevfileV2Spec `json:"devfileV2,omitempty"`
+	// Reference to a WorkspaceTemplate whose devfile is used as the base of the workspace's devfile. Components,
+	// commands and projects of the workspace's devfile override those of the template with the same key (component
+	// alias, command name and project name) and extend the template's otherwise. The workspace's devfile must use the
+	// same format as the template's.
+	Template *KubernetesReference `json:"template,omitempty"`
+	// Node labels the workspace pod must match to be scheduled on a node. Merged with the defaults from the controller
+	// config, taking precedence over them.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the workspace pod, added to the defaults from the controller config
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Scheduling constraints of the workspace pod. Replaces the default affinity from the controller config.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Name of the PriorityClass of the workspace pod. Defaults to the priority class from the controller config.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Name of the RuntimeClass used to run the workspace pod. Defaults to the runtime class from the controller config.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// Security context of the workspace pod. Fields that are set override the defaults from the controller config,
+	// subject to the security context policy of the controller config.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Security context of the workspace containers. Fields that are set override the defaults from the controller
+	// config, subject to the security context policy of the controller config.
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+	// Duration in seconds the workspace's containers are given to terminate gracefully when the workspace is stopped or
+	// restarted. Defaults to 1 second, or 30 seconds if the workspace has preStop commands.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Defines how changes that require restarting a running workspace are applied. With Immediate, the default, the
+	// workspace is restarted as soon as it changes. With OnConfirmation, changes to the spec of a running workspace
+	// are not applied to any of its objects until the workspace is annotated with
+	// org.eclipse.che.workspace/confirm-restart=true.
+	UpdatePolicy WorkspaceUpdatePolicy `json:"updatePolicy,omitempty"`
+	// Users and groups the workspace is shared with, in addition to its creator. They are allowed to exec into the
+	// workspace's containers and to access its endpoints secured by the openshift-oauth routing class. Only the
+	// workspace's creator may change it.
+	SharedWith *WorkspaceSharing `json:"sharedWith,omitempty"`
 }
 
+// WorkspaceSharing lists the users and groups a workspace is shared with
+type WorkspaceSharing struct {
+	// Names of the users the workspace is shared with
+	Users []string `json:"users,omitempty"`
+	// Names of the groups the workspace is shared with
+	Groups []string `json:"groups,omitempty"`
+}
+
+type WorkspaceUpdatePolicy string
+
+const (
+	WorkspaceUpdateImmediate      WorkspaceUpdatePolicy = "Immediate"
+	WorkspaceUpdateOnConfirmation WorkspaceUpdatePolicy = "OnConfirmation"
+)
+
 // WorkspaceStatus defines the observed state of Workspace
 // +k8s:openapi-gen=true
 type WorkspaceStatus struct {
@@ -37,6 +90,8 @@ type WorkspaceStatus struct {
 	IdeUrl      string         `json:"ideUrl"`
 	// Conditions represent the latest available observations of an object's state
 	Conditions []WorkspaceCondition `json:"conditions,omitempty"`
+	// Total resources requested by the workspace's containers, computed before the workspace deployment is created
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // WorkspaceCondition contains details for the current condition of this workspace.
@@ -73,6 +128,16 @@ const (
 	WorkspaceRoutingReady        WorkspaceConditionType = "RoutingReady"
 	WorkspaceServiceAccountReady WorkspaceConditionType = "ServiceAccountReady"
 	WorkspaceReady               WorkspaceConditionType = "Ready"
+	// Commands bound to the postStart and preStop devfile events are run by the workspace's containers. If the
+	// commands fail, the condition is false and its message contains their output.
+	WorkspacePostStartCommandsSucceeded WorkspaceConditionType = "PostStartCommandsSucceeded"
+	WorkspacePreStopCommandsSucceeded   WorkspaceConditionType = "PreStopCommandsSucceeded"
+	// Changes to a running workspace are applied by restarting it. While the restart is pending or in progress, the
+	// condition is false and its message describes why.
+	WorkspaceSpecApplied WorkspaceConditionType = "SpecApplied"
+	// Workspaces are only started if the quotas of their namespace and creator allow it. While the start is queued
+	// because quotas are exceeded, the condition is false and its message describes the exceeded quota.
+	WorkspaceQuotaAvailable WorkspaceConditionType = "QuotaAvailable"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -80,6 +145,7 @@ const (
 // Workspace is the Schema for the workspaces API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:path=workspaces,scope=Namespaced
 // +kubebuilder:printcolumn:name="Workspace ID",type="string",JSONPath=".status.workspaceId",description="The workspace's unique id"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current workspace startup phase"
@@ -0,0 +1,77 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceEventSpec describes a requested change of a workspace: who requested it, when and how
+// +k8s:openapi-gen=true
+type WorkspaceEventSpec struct {
+	// Name of the changed workspace
+	WorkspaceName string `json:"workspaceName"`
+	// ID of the changed workspace
+	WorkspaceId string `json:"workspaceId,omitempty"`
+	// Operation performed on the workspace
+	Operation WorkspaceOperation `json:"operation"`
+	// Name of the user who requested the change
+	Username string `json:"username"`
+	// UID of the user who requested the change
+	UserUID string `json:"userUid,omitempty"`
+	// Groups of the user who requested the change
+	Groups []string `json:"groups,omitempty"`
+	// Time at which the change was requested
+	Timestamp metav1.Time `json:"timestamp"`
+	// Difference between the old and the requested workspace spec
+	SpecDiff string `json:"specDiff,omitempty"`
+}
+
+type WorkspaceOperation string
+
+const (
+	WorkspaceOperationStart  WorkspaceOperation = "Start"
+	WorkspaceOperationStop   WorkspaceOperation = "Stop"
+	WorkspaceOperationUpdate WorkspaceOperation = "Update"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkspaceEvent records a requested change of a workspace. Events are created by the webhook server once it allows a
+// change, which may still be rejected later, e.g. by other admission webhooks or because of a conflict, so that an
+// event does not guarantee that the change was applied. Only the latest events of each workspace are kept.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=workspaceevents,scope=Namespaced
+// +kubebuilder:printcolumn:name="Workspace",type="string",JSONPath=".spec.workspaceName",description="The changed workspace"
+// +kubebuilder:printcolumn:name="Operation",type="string",JSONPath=".spec.operation",description="The operation performed on the workspace"
+// +kubebuilder:printcolumn:name="User",type="string",JSONPath=".spec.username",description="The user who requested the change"
+// +kubebuilder:printcolumn:name="Time",type="date",JSONPath=".spec.timestamp",description="The time of the request"
+type WorkspaceEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceEventSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkspaceEventList contains a list of WorkspaceEvent
+type WorkspaceEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceEvent{}, &WorkspaceEventList{})
+}
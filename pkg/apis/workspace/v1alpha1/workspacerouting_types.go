@@ -29,6 +29,11 @@ type WorkspaceRoutingSpec struct {
 	Endpoints map[string]EndpointList `json:"endpoints"`
 	// Selector that should be used by created services to point to the workspace Pod
 	PodSelector map[string]string `json:"podSelector"`
+	// Users allowed to access endpoints that require authentication. If neither users nor groups are set, any
+	// authenticated user is allowed.
+	AllowedUsers []string `json:"allowedUsers,omitempty"`
+	// Groups whose members are allowed to access endpoints that require authentication
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
 }
 
 type WorkspaceRoutingClass string
@@ -0,0 +1,52 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceTemplateSpec defines a devfile that can be shared by multiple workspaces
+// +k8s:openapi-gen=true
+type WorkspaceTemplateSpec struct {
+	// Devfile of the template in the Devfile format syntax. Exactly one of devfile and devfileV2 must be set.
+	Devfile *DevfileSpec `json:"devfile,omitempty"`
+	// Devfile of the template in the Devfile 2.0 format syntax. Exactly one of devfile and devfileV2 must be set.
+	// Templates cannot have a parent.
+	DevfileV2 *DevfileV2Spec `json:"devfileV2,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkspaceTemplate is the Schema for the workspacetemplates API
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=workspacetemplates,scope=Namespaced
+type WorkspaceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkspaceTemplateList contains a list of WorkspaceTemplate
+type WorkspaceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceTemplate{}, &WorkspaceTemplateList{})
+}
@@ -19,6 +19,11 @@ func (in *CheWorkspaceCommand) DeepCopyInto(out *CheWorkspaceCommand) {
 			(*out)[key] = val
 		}
 	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -165,6 +170,11 @@ func (in *CommandSpec) DeepCopyInto(out *CommandSpec) {
 		*out = make([]CommandActionSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
 		*out = make(map[string]string, len(*in))
@@ -314,13 +324,25 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]Env, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]Volume, len(*in))
 		copy(*out, *in)
 	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]ObjectMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]ObjectMount, len(*in))
+		copy(*out, *in)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = make([]string, len(*in))
@@ -400,6 +422,42 @@ func (in *DevfileAttributes) DeepCopy() *DevfileAttributes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevfileEvents) DeepCopyInto(out *DevfileEvents) {
+	*out = *in
+	if in.PreStart != nil {
+		in, out := &in.PreStart, &out.PreStart
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostStart != nil {
+		in, out := &in.PostStart, &out.PostStart
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostStop != nil {
+		in, out := &in.PostStop, &out.PostStop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileEvents.
+func (in *DevfileEvents) DeepCopy() *DevfileEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevfileMeta) DeepCopyInto(out *DevfileMeta) {
 	*out = *in
@@ -440,6 +498,11 @@ func (in *DevfileSpec) DeepCopyInto(out *DevfileSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(DevfileEvents)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -454,271 +517,275 @@ func (in *DevfileSpec) DeepCopy() *DevfileSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+func (in *DevfileV2ApplyCommand) DeepCopyInto(out *DevfileV2ApplyCommand) {
 	*out = *in
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[EndpointAttribute]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(DevfileV2CommandGroup)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2ApplyCommand.
+func (in *DevfileV2ApplyCommand) DeepCopy() *DevfileV2ApplyCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoint)
+	out := new(DevfileV2ApplyCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in EndpointList) DeepCopyInto(out *EndpointList) {
-	{
-		in := &in
-		*out = make(EndpointList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-		return
+func (in *DevfileV2Command) DeepCopyInto(out *DevfileV2Command) {
+	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(DevfileV2ExecCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(DevfileV2ApplyCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Composite != nil {
+		in, out := &in.Composite, &out.Composite
+		*out = new(DevfileV2CompositeCommand)
+		(*in).DeepCopyInto(*out)
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointList.
-func (in EndpointList) DeepCopy() EndpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Command.
+func (in *DevfileV2Command) DeepCopy() *DevfileV2Command {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointList)
+	out := new(DevfileV2Command)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Env) DeepCopyInto(out *Env) {
+func (in *DevfileV2CommandGroup) DeepCopyInto(out *DevfileV2CommandGroup) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Env.
-func (in *Env) DeepCopy() *Env {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2CommandGroup.
+func (in *DevfileV2CommandGroup) DeepCopy() *DevfileV2CommandGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(Env)
+	out := new(DevfileV2CommandGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExposedEndpoint) DeepCopyInto(out *ExposedEndpoint) {
+func (in *DevfileV2Component) DeepCopyInto(out *DevfileV2Component) {
 	*out = *in
-	if in.Attributes != nil {
-		in, out := &in.Attributes, &out.Attributes
-		*out = make(map[EndpointAttribute]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(DevfileV2ContainerComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(DevfileV2VolumeComponent)
+		**out = **in
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(DevfileV2KubernetesComponent)
+		**out = **in
+	}
+	if in.Openshift != nil {
+		in, out := &in.Openshift, &out.Openshift
+		*out = new(DevfileV2KubernetesComponent)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(DevfileV2PluginComponent)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedEndpoint.
-func (in *ExposedEndpoint) DeepCopy() *ExposedEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Component.
+func (in *DevfileV2Component) DeepCopy() *DevfileV2Component {
 	if in == nil {
 		return nil
 	}
-	out := new(ExposedEndpoint)
+	out := new(DevfileV2Component)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ExposedEndpointList) DeepCopyInto(out *ExposedEndpointList) {
-	{
-		in := &in
-		*out = make(ExposedEndpointList, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-		return
+func (in *DevfileV2CompositeCommand) DeepCopyInto(out *DevfileV2CompositeCommand) {
+	*out = *in
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(DevfileV2CommandGroup)
+		**out = **in
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedEndpointList.
-func (in ExposedEndpointList) DeepCopy() ExposedEndpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2CompositeCommand.
+func (in *DevfileV2CompositeCommand) DeepCopy() *DevfileV2CompositeCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(ExposedEndpointList)
+	out := new(DevfileV2CompositeCommand)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodAdditions) DeepCopyInto(out *PodAdditions) {
+func (in *DevfileV2ContainerComponent) DeepCopyInto(out *DevfileV2ContainerComponent) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MountSources != nil {
+		in, out := &in.MountSources, &out.MountSources
+		*out = new(bool)
+		**out = **in
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]DevfileV2Endpoint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]Env, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+		*out = make([]DevfileV2VolumeMount, len(*in))
+		copy(*out, *in)
 	}
-	if in.PullSecrets != nil {
-		in, out := &in.PullSecrets, &out.PullSecrets
-		*out = make([]v1.LocalObjectReference, len(*in))
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ServiceAccountAnnotations != nil {
-		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAdditions.
-func (in *PodAdditions) DeepCopy() *PodAdditions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2ContainerComponent.
+func (in *DevfileV2ContainerComponent) DeepCopy() *DevfileV2ContainerComponent {
 	if in == nil {
 		return nil
 	}
-	out := new(PodAdditions)
+	out := new(DevfileV2ContainerComponent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectSourceSpec) DeepCopyInto(out *ProjectSourceSpec) {
+func (in *DevfileV2Endpoint) DeepCopyInto(out *DevfileV2Endpoint) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSourceSpec.
-func (in *ProjectSourceSpec) DeepCopy() *ProjectSourceSpec {
-	if in == nil {
-		return nil
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	out := new(ProjectSourceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
-	*out = *in
-	out.Source = in.Source
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
-func (in *ProjectSpec) DeepCopy() *ProjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Endpoint.
+func (in *DevfileV2Endpoint) DeepCopy() *DevfileV2Endpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectSpec)
+	out := new(DevfileV2Endpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Volume) DeepCopyInto(out *Volume) {
+func (in *DevfileV2ExecCommand) DeepCopyInto(out *DevfileV2ExecCommand) {
 	*out = *in
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(DevfileV2CommandGroup)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
-func (in *Volume) DeepCopy() *Volume {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2ExecCommand.
+func (in *DevfileV2ExecCommand) DeepCopy() *DevfileV2ExecCommand {
 	if in == nil {
 		return nil
 	}
-	out := new(Volume)
+	out := new(DevfileV2ExecCommand)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Workspace) DeepCopyInto(out *Workspace) {
+func (in *DevfileV2KubernetesComponent) DeepCopyInto(out *DevfileV2KubernetesComponent) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workspace.
-func (in *Workspace) DeepCopy() *Workspace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2KubernetesComponent.
+func (in *DevfileV2KubernetesComponent) DeepCopy() *DevfileV2KubernetesComponent {
 	if in == nil {
 		return nil
 	}
-	out := new(Workspace)
+	out := new(DevfileV2KubernetesComponent)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Workspace) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceComponentSpec) DeepCopyInto(out *WorkspaceComponentSpec) {
+func (in *DevfileV2Parent) DeepCopyInto(out *DevfileV2Parent) {
 	*out = *in
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesReference)
+		**out = **in
+	}
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
-		*out = make([]ComponentSpec, len(*in))
+		*out = make([]DevfileV2Component, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Commands != nil {
 		in, out := &in.Commands, &out.Commands
-		*out = make([]CommandSpec, len(*in))
+		*out = make([]DevfileV2Command, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]DevfileV2Project, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -726,48 +793,684 @@ func (in *WorkspaceComponentSpec) DeepCopyInto(out *WorkspaceComponentSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceComponentSpec.
-func (in *WorkspaceComponentSpec) DeepCopy() *WorkspaceComponentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Parent.
+func (in *DevfileV2Parent) DeepCopy() *DevfileV2Parent {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceComponentSpec)
+	out := new(DevfileV2Parent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceComponentStatus) DeepCopyInto(out *WorkspaceComponentStatus) {
+func (in *DevfileV2PluginComponent) DeepCopyInto(out *DevfileV2PluginComponent) {
 	*out = *in
-	if in.ComponentDescriptions != nil {
-		in, out := &in.ComponentDescriptions, &out.ComponentDescriptions
-		*out = make([]ComponentDescription, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceComponentStatus.
-func (in *WorkspaceComponentStatus) DeepCopy() *WorkspaceComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2PluginComponent.
+func (in *DevfileV2PluginComponent) DeepCopy() *DevfileV2PluginComponent {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceComponentStatus)
+	out := new(DevfileV2PluginComponent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceCondition) DeepCopyInto(out *WorkspaceCondition) {
+func (in *DevfileV2Project) DeepCopyInto(out *DevfileV2Project) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceCondition.
-func (in *WorkspaceCondition) DeepCopy() *WorkspaceCondition {
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(DevfileV2ProjectSource)
+		**out = **in
+	}
+	if in.Github != nil {
+		in, out := &in.Github, &out.Github
+		*out = new(DevfileV2ProjectSource)
+		**out = **in
+	}
+	if in.Zip != nil {
+		in, out := &in.Zip, &out.Zip
+		*out = new(DevfileV2ProjectSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Project.
+func (in *DevfileV2Project) DeepCopy() *DevfileV2Project {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileV2Project)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevfileV2ProjectSource) DeepCopyInto(out *DevfileV2ProjectSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2ProjectSource.
+func (in *DevfileV2ProjectSource) DeepCopy() *DevfileV2ProjectSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileV2ProjectSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevfileV2Spec) DeepCopyInto(out *DevfileV2Spec) {
+	*out = *in
+	out.Metadata = in.Metadata
+	if in.Parent != nil {
+		in, out := &in.Parent, &out.Parent
+		*out = new(DevfileV2Parent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]DevfileV2Project, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]DevfileV2Component, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]DevfileV2Command, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(DevfileEvents)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2Spec.
+func (in *DevfileV2Spec) DeepCopy() *DevfileV2Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileV2Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevfileV2VolumeComponent) DeepCopyInto(out *DevfileV2VolumeComponent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2VolumeComponent.
+func (in *DevfileV2VolumeComponent) DeepCopy() *DevfileV2VolumeComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileV2VolumeComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevfileV2VolumeMount) DeepCopyInto(out *DevfileV2VolumeMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevfileV2VolumeMount.
+func (in *DevfileV2VolumeMount) DeepCopy() *DevfileV2VolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(DevfileV2VolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[EndpointAttribute]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in EndpointList) DeepCopyInto(out *EndpointList) {
+	{
+		in := &in
+		*out = make(EndpointList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointList.
+func (in EndpointList) DeepCopy() EndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointList)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Env) DeepCopyInto(out *Env) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Env.
+func (in *Env) DeepCopy() *Env {
+	if in == nil {
+		return nil
+	}
+	out := new(Env)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarSource) DeepCopyInto(out *EnvVarSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarSource.
+func (in *EnvVarSource) DeepCopy() *EnvVarSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposedEndpoint) DeepCopyInto(out *ExposedEndpoint) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[EndpointAttribute]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedEndpoint.
+func (in *ExposedEndpoint) DeepCopy() *ExposedEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposedEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ExposedEndpointList) DeepCopyInto(out *ExposedEndpointList) {
+	{
+		in := &in
+		*out = make(ExposedEndpointList, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedEndpointList.
+func (in ExposedEndpointList) DeepCopy() ExposedEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposedEndpointList)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePuller) DeepCopyInto(out *ImagePuller) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePuller.
+func (in *ImagePuller) DeepCopy() *ImagePuller {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePuller)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePuller) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullerImageStatus) DeepCopyInto(out *ImagePullerImageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullerImageStatus.
+func (in *ImagePullerImageStatus) DeepCopy() *ImagePullerImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullerImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullerList) DeepCopyInto(out *ImagePullerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImagePuller, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullerList.
+func (in *ImagePullerList) DeepCopy() *ImagePullerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePullerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullerSpec) DeepCopyInto(out *ImagePullerSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullerSpec.
+func (in *ImagePullerSpec) DeepCopy() *ImagePullerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullerStatus) DeepCopyInto(out *ImagePullerStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImagePullerImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullerStatus.
+func (in *ImagePullerStatus) DeepCopy() *ImagePullerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeySelector) DeepCopyInto(out *KeySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeySelector.
+func (in *KeySelector) DeepCopy() *KeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesReference) DeepCopyInto(out *KubernetesReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesReference.
+func (in *KubernetesReference) DeepCopy() *KubernetesReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMount) DeepCopyInto(out *ObjectMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMount.
+func (in *ObjectMount) DeepCopy() *ObjectMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAdditions) DeepCopyInto(out *PodAdditions) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAdditions.
+func (in *PodAdditions) DeepCopy() *PodAdditions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAdditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSourceSpec) DeepCopyInto(out *ProjectSourceSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSourceSpec.
+func (in *ProjectSourceSpec) DeepCopy() *ProjectSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
+	*out = *in
+	out.Source = in.Source
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
+func (in *ProjectSpec) DeepCopy() *ProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Volume) DeepCopyInto(out *Volume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
+func (in *Volume) DeepCopy() *Volume {
+	if in == nil {
+		return nil
+	}
+	out := new(Volume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workspace) DeepCopyInto(out *Workspace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workspace.
+func (in *Workspace) DeepCopy() *Workspace {
+	if in == nil {
+		return nil
+	}
+	out := new(Workspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workspace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceComponentSpec) DeepCopyInto(out *WorkspaceComponentSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]CommandSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(DevfileEvents)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceComponentSpec.
+func (in *WorkspaceComponentSpec) DeepCopy() *WorkspaceComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceComponentStatus) DeepCopyInto(out *WorkspaceComponentStatus) {
+	*out = *in
+	if in.ComponentDescriptions != nil {
+		in, out := &in.ComponentDescriptions, &out.ComponentDescriptions
+		*out = make([]ComponentDescription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceComponentStatus.
+func (in *WorkspaceComponentStatus) DeepCopy() *WorkspaceComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceCondition) DeepCopyInto(out *WorkspaceCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceCondition.
+func (in *WorkspaceCondition) DeepCopy() *WorkspaceCondition {
 	if in == nil {
 		return nil
 	}
@@ -776,6 +1479,88 @@ func (in *WorkspaceCondition) DeepCopy() *WorkspaceCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceEvent) DeepCopyInto(out *WorkspaceEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceEvent.
+func (in *WorkspaceEvent) DeepCopy() *WorkspaceEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceEventList) DeepCopyInto(out *WorkspaceEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceEventList.
+func (in *WorkspaceEventList) DeepCopy() *WorkspaceEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceEventSpec) DeepCopyInto(out *WorkspaceEventSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceEventSpec.
+func (in *WorkspaceEventSpec) DeepCopy() *WorkspaceEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceList) DeepCopyInto(out *WorkspaceList) {
 	*out = *in
@@ -897,6 +1682,16 @@ func (in *WorkspaceRoutingSpec) DeepCopyInto(out *WorkspaceRoutingSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.AllowedUsers != nil {
+		in, out := &in.AllowedUsers, &out.AllowedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -948,10 +1743,94 @@ func (in *WorkspaceRoutingStatus) DeepCopy() *WorkspaceRoutingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceSharing) DeepCopyInto(out *WorkspaceSharing) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSharing.
+func (in *WorkspaceSharing) DeepCopy() *WorkspaceSharing {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceSharing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 	*out = *in
-	in.Devfile.DeepCopyInto(&out.Devfile)
+	if in.Devfile != nil {
+		in, out := &in.Devfile, &out.Devfile
+		*out = new(DevfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevfileV2 != nil {
+		in, out := &in.DevfileV2, &out.DevfileV2
+		*out = new(DevfileV2Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(KubernetesReference)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SharedWith != nil {
+		in, out := &in.SharedWith, &out.SharedWith
+		*out = new(WorkspaceSharing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -975,6 +1854,11 @@ func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -987,3 +1871,89 @@ func (in *WorkspaceStatus) DeepCopy() *WorkspaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceTemplate) DeepCopyInto(out *WorkspaceTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTemplate.
+func (in *WorkspaceTemplate) DeepCopy() *WorkspaceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceTemplateList) DeepCopyInto(out *WorkspaceTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTemplateList.
+func (in *WorkspaceTemplateList) DeepCopy() *WorkspaceTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceTemplateSpec) DeepCopyInto(out *WorkspaceTemplateSpec) {
+	*out = *in
+	if in.Devfile != nil {
+		in, out := &in.Devfile, &out.Devfile
+		*out = new(DevfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DevfileV2 != nil {
+		in, out := &in.DevfileV2, &out.DevfileV2
+		*out = new(DevfileV2Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTemplateSpec.
+func (in *WorkspaceTemplateSpec) DeepCopy() *WorkspaceTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,14 +24,21 @@ import (
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
 		"./pkg/apis/workspace/v1alpha1.Component":                schema_pkg_apis_workspace_v1alpha1_Component(ref),
+		"./pkg/apis/workspace/v1alpha1.ImagePuller":              schema_pkg_apis_workspace_v1alpha1_ImagePuller(ref),
+		"./pkg/apis/workspace/v1alpha1.ImagePullerSpec":          schema_pkg_apis_workspace_v1alpha1_ImagePullerSpec(ref),
+		"./pkg/apis/workspace/v1alpha1.ImagePullerStatus":        schema_pkg_apis_workspace_v1alpha1_ImagePullerStatus(ref),
 		"./pkg/apis/workspace/v1alpha1.Workspace":                schema_pkg_apis_workspace_v1alpha1_Workspace(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceComponentSpec":   schema_pkg_apis_workspace_v1alpha1_WorkspaceComponentSpec(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceComponentStatus": schema_pkg_apis_workspace_v1alpha1_WorkspaceComponentStatus(ref),
+		"./pkg/apis/workspace/v1alpha1.WorkspaceEvent":           schema_pkg_apis_workspace_v1alpha1_WorkspaceEvent(ref),
+		"./pkg/apis/workspace/v1alpha1.WorkspaceEventSpec":       schema_pkg_apis_workspace_v1alpha1_WorkspaceEventSpec(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceRouting":         schema_pkg_apis_workspace_v1alpha1_WorkspaceRouting(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceRoutingSpec":     schema_pkg_apis_workspace_v1alpha1_WorkspaceRoutingSpec(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceRoutingStatus":   schema_pkg_apis_workspace_v1alpha1_WorkspaceRoutingStatus(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceSpec":            schema_pkg_apis_workspace_v1alpha1_WorkspaceSpec(ref),
 		"./pkg/apis/workspace/v1alpha1.WorkspaceStatus":          schema_pkg_apis_workspace_v1alpha1_WorkspaceStatus(ref),
+		"./pkg/apis/workspace/v1alpha1.WorkspaceTemplate":        schema_pkg_apis_workspace_v1alpha1_WorkspaceTemplate(ref),
+		"./pkg/apis/workspace/v1alpha1.WorkspaceTemplateSpec":    schema_pkg_apis_workspace_v1alpha1_WorkspaceTemplateSpec(ref),
 	}
 }
 
@@ -79,6 +86,120 @@ func schema_pkg_apis_workspace_v1alpha1_Component(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_workspace_v1alpha1_ImagePuller(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImagePuller is the Schema for the imagepullers API. The controller manages a single ImagePuller in its namespace when the image puller is enabled in the controller config.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha1.ImagePullerSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha1.ImagePullerStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.ImagePullerSpec", "./pkg/apis/workspace/v1alpha1.ImagePullerStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha1_ImagePullerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImagePullerSpec defines images cached on every node in addition to those collected by the controller",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"images": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Additional images to cache on every node",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha1_ImagePullerStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ImagePullerStatus defines the observed state of the image puller",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"images": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Images cached by the image puller",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/workspace/v1alpha1.ImagePullerImageStatus"),
+									},
+								},
+							},
+						},
+					},
+					"desiredNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of nodes that should run the image puller",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyNodes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of nodes where all images are cached",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"desiredNodes", "readyNodes"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.ImagePullerImageStatus"},
+	}
+}
+
 func schema_pkg_apis_workspace_v1alpha1_Workspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -173,12 +294,18 @@ func schema_pkg_apis_workspace_v1alpha1_WorkspaceComponentSpec(ref common.Refere
 							},
 						},
 					},
+					"events": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bindings of commands from devfile to events in the workspace lifecycle",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileEvents"),
+						},
+					},
 				},
 				Required: []string{"workspaceId", "components"},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/workspace/v1alpha1.CommandSpec", "./pkg/apis/workspace/v1alpha1.ComponentSpec"},
+			"./pkg/apis/workspace/v1alpha1.CommandSpec", "./pkg/apis/workspace/v1alpha1.ComponentSpec", "./pkg/apis/workspace/v1alpha1.DevfileEvents"},
 	}
 }
 
@@ -223,6 +350,123 @@ func schema_pkg_apis_workspace_v1alpha1_WorkspaceComponentStatus(ref common.Refe
 	}
 }
 
+func schema_pkg_apis_workspace_v1alpha1_WorkspaceEvent(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceEvent records a requested change of a workspace. Events are created by the webhook server once it allows a change, which may still be rejected later, e.g. by other admission webhooks or because of a conflict, so that an event does not guarantee that the change was applied. Only the latest events of each workspace are kept.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha1.WorkspaceEventSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.WorkspaceEventSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha1_WorkspaceEventSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceEventSpec describes a requested change of a workspace: who requested it, when and how",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"workspaceName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the changed workspace",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"workspaceId": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID of the changed workspace",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"operation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Operation performed on the workspace",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"username": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the user who requested the change",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"userUid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UID of the user who requested the change",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups of the user who requested the change",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"timestamp": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time at which the change was requested",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"specDiff": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Difference between the old and the requested workspace spec",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"workspaceName", "operation", "username", "timestamp"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_pkg_apis_workspace_v1alpha1_WorkspaceRouting(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -331,6 +575,34 @@ func schema_pkg_apis_workspace_v1alpha1_WorkspaceRoutingSpec(ref common.Referenc
 							},
 						},
 					},
+					"allowedUsers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Users allowed to access endpoints that require authentication. If neither users nor groups are set, any authenticated user is allowed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"allowedGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups whose members are allowed to access endpoints that require authentication",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"workspaceId", "routingSuffix", "endpoints", "podSelector"},
 			},
@@ -412,16 +684,108 @@ func schema_pkg_apis_workspace_v1alpha1_WorkspaceSpec(ref common.ReferenceCallba
 					},
 					"devfile": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Workspace Structure defined in the Devfile format syntax. For more details see the Che 7 documentation: https://www.eclipse.org/che/docs/che-7/making-a-workspace-portable-using-a-devfile/",
+							Description: "Workspace Structure defined in the Devfile format syntax. For more details see the Che 7 documentation: https://www.eclipse.org/che/docs/che-7/making-a-workspace-portable-using-a-devfile/ Exactly one of devfile and devfileV2 must be set, unless the workspace uses a template.",
 							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileSpec"),
 						},
 					},
+					"devfileV2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workspace Structure defined in the Devfile 2.0 format syntax. Exactly one of devfile and devfileV2 must be set, unless the workspace uses a template.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileV2Spec"),
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reference to a WorkspaceTemplate whose devfile is used as the base of the workspace's devfile. Components, commands and projects of the workspace's devfile override those of the template with the same key (component alias, command name and project name) and extend the template's otherwise. The workspace's devfile must use the same format as the template's.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.KubernetesReference"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node labels the workspace pod must match to be scheduled on a node. Merged with the defaults from the controller config, taking precedence over them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of the workspace pod, added to the defaults from the controller config",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduling constraints of the workspace pod. Replaces the default affinity from the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the PriorityClass of the workspace pod. Defaults to the priority class from the controller config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the RuntimeClass used to run the workspace pod. Defaults to the runtime class from the controller config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Security context of the workspace pod. Fields that are set override the defaults from the controller config, subject to the security context policy of the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"containerSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Security context of the workspace containers. Fields that are set override the defaults from the controller config, subject to the security context policy of the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.SecurityContext"),
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration in seconds the workspace's containers are given to terminate gracefully when the workspace is stopped or restarted. Defaults to 1 second, or 30 seconds if the workspace has preStop commands.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"updatePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Defines how changes that require restarting a running workspace are applied. With Immediate, the default, the workspace is restarted as soon as it changes. With OnConfirmation, changes to the spec of a running workspace are not applied to any of its objects until the workspace is annotated with org.eclipse.che.workspace/confirm-restart=true.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sharedWith": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Users and groups the workspace is shared with, in addition to its creator. They are allowed to exec into the workspace's containers and to access its endpoints secured by the openshift-oauth routing class. Only the workspace's creator may change it.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.WorkspaceSharing"),
+						},
+					},
 				},
-				Required: []string{"started", "devfile"},
+				Required: []string{"started"},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/workspace/v1alpha1.DevfileSpec"},
+			"./pkg/apis/workspace/v1alpha1.DevfileSpec", "./pkg/apis/workspace/v1alpha1.DevfileV2Spec", "./pkg/apis/workspace/v1alpha1.KubernetesReference", "./pkg/apis/workspace/v1alpha1.WorkspaceSharing", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -468,11 +832,83 @@ func schema_pkg_apis_workspace_v1alpha1_WorkspaceStatus(ref common.ReferenceCall
 							},
 						},
 					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total resources requested by the workspace's containers, computed before the workspace deployment is created",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
 				},
 				Required: []string{"workspaceId", "ideUrl"},
 			},
 		},
 		Dependencies: []string{
-			"./pkg/apis/workspace/v1alpha1.WorkspaceCondition"},
+			"./pkg/apis/workspace/v1alpha1.WorkspaceCondition", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha1_WorkspaceTemplate(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTemplate is the Schema for the workspacetemplates API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha1.WorkspaceTemplateSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.WorkspaceTemplateSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha1_WorkspaceTemplateSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceTemplateSpec defines a devfile that can be shared by multiple workspaces",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"devfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Devfile of the template in the Devfile format syntax. Exactly one of devfile and devfileV2 must be set.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileSpec"),
+						},
+					},
+					"devfileV2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Devfile of the template in the Devfile 2.0 format syntax. Exactly one of devfile and devfileV2 must be set. Templates cannot have a parent.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileV2Spec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.DevfileSpec", "./pkg/apis/workspace/v1alpha1.DevfileV2Spec"},
 	}
 }
@@ -0,0 +1,234 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation stores the v1alpha2 spec of a workspace when it cannot be represented in v1alpha1 without
+// loss (e.g. an explicit `persistVolumes: false`), so that converting it back to v1alpha2 yields the original spec.
+const ConversionDataAnnotation = "org.eclipse.che.workspace/v1alpha2-spec"
+
+var _ conversion.Convertible = &Workspace{}
+
+// ConvertTo converts this Workspace to the hub version (v1alpha1)
+func (src *Workspace) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Workspace)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	delete(dst.Annotations, ConversionDataAnnotation)
+
+	dstSpec, err := convertSpecTo(&src.Spec)
+	if err != nil {
+		return err
+	}
+	dst.Spec = *dstSpec
+	if err := convertViaJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	// Store the original spec if it does not survive a round trip, so that ConvertFrom can restore it
+	roundTripSpec, err := convertSpecFrom(dstSpec)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(roundTripSpec, &src.Spec) {
+		specJSON, err := json.Marshal(src.Spec)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[ConversionDataAnnotation] = string(specJSON)
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *Workspace) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Workspace)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	delete(dst.Annotations, ConversionDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	dstSpec, err := convertSpecFrom(&src.Spec)
+	if err != nil {
+		return err
+	}
+	dst.Spec = *dstSpec
+	if err := convertViaJSON(&src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	// Restore the stored spec only if the v1alpha1 spec was not modified since it was stored
+	if specJSON, ok := src.Annotations[ConversionDataAnnotation]; ok {
+		storedSpec := &WorkspaceSpec{}
+		if err := json.Unmarshal([]byte(specJSON), storedSpec); err == nil {
+			if storedHubSpec, err := convertSpecTo(storedSpec); err == nil && reflect.DeepEqual(storedHubSpec, &src.Spec) {
+				dst.Spec = *storedSpec
+			}
+		}
+	}
+	return nil
+}
+
+func convertSpecTo(src *WorkspaceSpec) (*v1alpha1.WorkspaceSpec, error) {
+	dst := &v1alpha1.WorkspaceSpec{
+		Started:      src.Started,
+		RoutingClass: v1alpha1.WorkspaceRoutingClass(src.RoutingClass),
+		UpdatePolicy: v1alpha1.WorkspaceUpdatePolicy(src.UpdatePolicy),
+	}
+	if src.Devfile != nil {
+		dst.Devfile = &v1alpha1.DevfileSpec{}
+		if err := convertViaJSON(src.Devfile, dst.Devfile); err != nil {
+			return nil, err
+		}
+		for i, component := range src.Devfile.Components {
+			for j, endpoint := range component.Endpoints {
+				dst.Devfile.Components[i].Endpoints[j].Attributes = convertEndpointAttributesTo(endpoint)
+			}
+		}
+	}
+	// Both versions share the devfile 2.0 types
+	dst.DevfileV2 = src.DevfileV2.DeepCopy()
+	dst.Template = src.Template.DeepCopy()
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Affinity = src.Affinity
+	dst.PriorityClassName = src.PriorityClassName
+	dst.RuntimeClassName = src.RuntimeClassName
+	dst.PodSecurityContext = src.PodSecurityContext
+	dst.ContainerSecurityContext = src.ContainerSecurityContext
+	dst.TerminationGracePeriodSeconds = src.TerminationGracePeriodSeconds
+	if src.SharedWith != nil {
+		dst.SharedWith = &v1alpha1.WorkspaceSharing{
+			Users:  src.SharedWith.Users,
+			Groups: src.SharedWith.Groups,
+		}
+	}
+	return dst, nil
+}
+
+func convertSpecFrom(src *v1alpha1.WorkspaceSpec) (*WorkspaceSpec, error) {
+	dst := &WorkspaceSpec{
+		Started:      src.Started,
+		RoutingClass: WorkspaceRoutingClass(src.RoutingClass),
+		UpdatePolicy: WorkspaceUpdatePolicy(src.UpdatePolicy),
+	}
+	if src.Devfile != nil {
+		dst.Devfile = &DevfileSpec{}
+		if err := convertViaJSON(src.Devfile, dst.Devfile); err != nil {
+			return nil, err
+		}
+		for i, component := range src.Devfile.Components {
+			for j, endpoint := range component.Endpoints {
+				convertEndpointAttributesFrom(endpoint.Attributes, &dst.Devfile.Components[i].Endpoints[j])
+			}
+		}
+	}
+	// Both versions share the devfile 2.0 types
+	dst.DevfileV2 = src.DevfileV2.DeepCopy()
+	dst.Template = src.Template.DeepCopy()
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Affinity = src.Affinity
+	dst.PriorityClassName = src.PriorityClassName
+	dst.RuntimeClassName = src.RuntimeClassName
+	dst.PodSecurityContext = src.PodSecurityContext
+	dst.ContainerSecurityContext = src.ContainerSecurityContext
+	dst.TerminationGracePeriodSeconds = src.TerminationGracePeriodSeconds
+	if src.SharedWith != nil {
+		dst.SharedWith = &WorkspaceSharing{
+			Users:  src.SharedWith.Users,
+			Groups: src.SharedWith.Groups,
+		}
+	}
+	return dst, nil
+}
+
+func convertEndpointAttributesTo(endpoint Endpoint) map[v1alpha1.EndpointAttribute]string {
+	attributes := map[v1alpha1.EndpointAttribute]string{}
+	for key, value := range endpoint.Attributes {
+		attributes[v1alpha1.EndpointAttribute(key)] = value
+	}
+	setBoolAttribute := func(attribute v1alpha1.EndpointAttribute, value *bool) {
+		if value != nil {
+			attributes[attribute] = strconv.FormatBool(*value)
+		}
+	}
+	setStringAttribute := func(attribute v1alpha1.EndpointAttribute, value string) {
+		if value != "" {
+			attributes[attribute] = value
+		}
+	}
+	setBoolAttribute(v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE, endpoint.Public)
+	setBoolAttribute(v1alpha1.SECURE_ENDPOINT_ATTRIBUTE, endpoint.Secure)
+	setBoolAttribute(v1alpha1.DISCOVERABLE_ATTRIBUTE, endpoint.Discoverable)
+	setBoolAttribute(v1alpha1.SHARED_ENDPOINT_ATTRIBUTE, endpoint.Shared)
+	setStringAttribute(v1alpha1.TYPE_ENDPOINT_ATTRIBUTE, string(endpoint.Type))
+	setStringAttribute(v1alpha1.PROTOCOL_ENDPOINT_ATTRIBUTE, endpoint.Protocol)
+	setStringAttribute(v1alpha1.PATH_ENDPOINT_ATTRIBUTE, endpoint.Path)
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
+// convertEndpointAttributesFrom sets the typed fields of endpoint from v1alpha1 attributes. Attributes that do not
+// have a typed field, or whose value cannot be parsed, are kept in the endpoint's attributes.
+func convertEndpointAttributesFrom(attributes map[v1alpha1.EndpointAttribute]string, endpoint *Endpoint) {
+	endpoint.Attributes = map[string]string{}
+	for key, value := range attributes {
+		endpoint.Attributes[string(key)] = value
+	}
+	takeBoolAttribute := func(attribute v1alpha1.EndpointAttribute) *bool {
+		value, err := strconv.ParseBool(attributes[attribute])
+		if err != nil {
+			return nil
+		}
+		delete(endpoint.Attributes, string(attribute))
+		return &value
+	}
+	takeStringAttribute := func(attribute v1alpha1.EndpointAttribute) string {
+		value := attributes[attribute]
+		delete(endpoint.Attributes, string(attribute))
+		return value
+	}
+	endpoint.Public = takeBoolAttribute(v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE)
+	endpoint.Secure = takeBoolAttribute(v1alpha1.SECURE_ENDPOINT_ATTRIBUTE)
+	endpoint.Discoverable = takeBoolAttribute(v1alpha1.DISCOVERABLE_ATTRIBUTE)
+	endpoint.Shared = takeBoolAttribute(v1alpha1.SHARED_ENDPOINT_ATTRIBUTE)
+	endpoint.Type = EndpointType(takeStringAttribute(v1alpha1.TYPE_ENDPOINT_ATTRIBUTE))
+	endpoint.Protocol = takeStringAttribute(v1alpha1.PROTOCOL_ENDPOINT_ATTRIBUTE)
+	endpoint.Path = takeStringAttribute(v1alpha1.PATH_ENDPOINT_ATTRIBUTE)
+	if len(endpoint.Attributes) == 0 {
+		endpoint.Attributes = nil
+	}
+}
+
+// convertViaJSON converts between types that have the same JSON representation in both versions
+func convertViaJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha2
+
+// This schema describes the structure of the devfile object
+type DevfileSpec struct {
+	// Devfile API version
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+
+	// Devfile metadata
+	DevfileMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	// Devfile attributes, e.g. persistVolumes
+	DevfileAttributes `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+
+	// List of projects that should be imported into the workspace
+	Projects []ProjectSpec `json:"projects,omitempty" yaml:"projects,omitempty"` // Description of the projects, containing names and sources locations
+
+	// List of components (editor, plugins, containers, ...) that will provide the workspace features
+	Components []ComponentSpec `json:"components" yaml:"components"` // Description of the workspace components, such as editor and plugins
+
+	// List of workspace-wide commands that can be associated to a given component, in order to run in the related container
+	Commands []CommandSpec `json:"commands,omitempty" yaml:"commands,omitempty"` // Description of the predefined commands to be available in workspace
+
+	// Bindings of commands to events in the workspace lifecycle
+	Events *DevfileEvents `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+type DevfileMeta struct {
+	GenerateName string `json:"generateName,omitempty" yaml:"generateName,omitempty"`
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type DevfileAttributes struct {
+	PersistVolumes *bool `json:"persistVolumes,omitempty" yaml:"persistVolumes,omitempty"` // Whether workspace volumes should be persisted. Defaults to false, as in v1alpha1
+	EditorFree     bool  `json:"editorFree,omitempty" yaml:"editorFree,omitempty"`         // Whether the workspace should be started without an editor
+}
+
+type ProjectSpec struct {
+	Name      string            `json:"name" yaml:"name"`
+	Source    ProjectSourceSpec `json:"source" yaml:"source"`                           // Describes the project's source - type and location
+	ClonePath string            `json:"clonePath,omitempty" yaml:"clonePath,omitempty"` // Path relative to the projects root where the project should be cloned. Defaults to the project name
+}
+
+// Describes the project's source - type and location
+type ProjectSourceSpec struct {
+	Location string `json:"location" yaml:"location"`                 // Project's source location address. Should be URL for git and github located projects, or; file:// for zip.
+	Type     string `json:"type" yaml:"type"`                         // Project's source type.
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"` // The branch to check out. Applicable only to git and github sources
+}
+
+type ComponentSpec struct {
+	//provision fields for all components types
+
+	Type  ComponentType `json:"type" yaml:"type"`                       // Describes type of the component, e.g. whether it is an plugin or editor or other type
+	Alias string        `json:"alias,omitempty" yaml:"alias,omitempty"` // Describes the name of the component. Should be unique per component set.
+
+	//provision fields for cheEditor&chePlugin types
+
+	Id string `json:"id,omitempty" yaml:"id,omitempty"` // Describes the component FQN
+
+	//provision fields for cheEditor&chePlugin&Kubernetes&OpenShift types
+
+	Reference string `json:"reference,omitempty" yaml:"reference,omitempty"` // Describes location of Kubernetes list yaml file. Applicable only for 'kubernetes' and; 'openshift' type components
+
+	//fields for dockerimage type
+
+	Image         string        `json:"image,omitempty" yaml:"image,omitempty"`                 // Specifies the docker image that should be used for component
+	MemoryLimit   string        `json:"memoryLimit,omitempty" yaml:"memoryLimit,omitempty"`     // Describes memory limit for the component. You can express memory as a plain integer or as a; fixed-point integer using one of these suffixes: E, P, T, G, M, K. You can also use the; power-of-two equivalents: Ei, Pi, Ti, Gi, Mi, Ki
+	MemoryRequest string        `json:"memoryRequest,omitempty" yaml:"memoryRequest,omitempty"` // Describes memory request for the component. Uses the same format as memoryLimit and must not exceed it
+	CpuLimit      string        `json:"cpuLimit,omitempty" yaml:"cpuLimit,omitempty"`           // Describes CPU limit for the component. You can express CPU as a plain number of cores (e.g. 0.5) or; in millicores using the m suffix (e.g. 500m)
+	CpuRequest    string        `json:"cpuRequest,omitempty" yaml:"cpuRequest,omitempty"`       // Describes CPU request for the component. Uses the same format as cpuLimit and must not exceed it
+	MountSources  bool          `json:"mountSources,omitempty" yaml:"mountSources,omitempty"`   // Describes whether projects sources should be mount to the component. `CHE_PROJECTS_ROOT`; environment variable should contains a path where projects sources are mount
+	Endpoints     []Endpoint    `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`         // Describes dockerimage component endpoints. For cheEditor and chePlugin components, overrides the plugin's endpoints with the same name
+	Env           []Env         `json:"env,omitempty" yaml:"env,omitempty"`                     // The environment variables list that should be set to docker container. For cheEditor and chePlugin components, set in all plugin containers
+	Volumes       []Volume      `json:"volumes,omitempty" yaml:"volumes,omitempty"`             // Describes volumes which should be mount to component. For cheEditor and chePlugin components, overrides the plugin's volumes with the same name
+	Secrets       []ObjectMount `json:"secrets,omitempty" yaml:"secrets,omitempty"`             // Describes Secrets which should be mounted as files to component
+	ConfigMaps    []ObjectMount `json:"configMaps,omitempty" yaml:"configMaps,omitempty"`       // Describes ConfigMaps which should be mounted as files to component
+	Command       []string      `json:"command,omitempty" yaml:"command,omitempty"`             // The command to run in the dockerimage component instead of the default one provided in the image. Defaults to null, meaning use whatever is defined in the image.
+	Args          []string      `json:"args,omitempty" yaml:"args,omitempty"`                   // The arguments to supply to the command running the dockerimage component. The arguments are supplied either to the default command provided in the image or to the overridden command. Defaults to null, meaning use whatever is defined in the image.
+
+	//provision fields for kubernetes&openshift types
+
+	ReferenceContent *string           `json:"referenceContent,omitempty" yaml:"referenceContent,omitempty"` // Inlined content of a file specified in field 'local'
+	Selector         map[string]string `json:"selector,omitempty" yaml:"selector,omitempty"`                 // Describes the objects selector for the recipe type components. Allows to pick-up only selected; items from k8s/openshift list
+}
+
+// Describes dockerimage component endpoint
+type Endpoint struct {
+	Name         string            `json:"name" yaml:"name"`                                     // The endpoint name
+	Port         int64             `json:"port" yaml:"port"`                                     // The endpoint port
+	Public       *bool             `json:"public,omitempty" yaml:"public,omitempty"`             // Whether the endpoint should be available outside of the workspace. Defaults to true
+	Secure       *bool             `json:"secure,omitempty" yaml:"secure,omitempty"`             // Whether the endpoint should be covered with authentication
+	Discoverable *bool             `json:"discoverable,omitempty" yaml:"discoverable,omitempty"` // Whether the endpoint should be accessible through a service named after the endpoint
+	Shared       *bool             `json:"shared,omitempty" yaml:"shared,omitempty"`             // Whether a discoverable endpoint should be accessible from other workspaces in the namespace
+	Type         EndpointType      `json:"type,omitempty" yaml:"type,omitempty"`                 // The endpoint type, e.g. terminal or ide
+	Protocol     string            `json:"protocol,omitempty" yaml:"protocol,omitempty"`         // The protocol used by the backend application, e.g. http or ws
+	Path         string            `json:"path,omitempty" yaml:"path,omitempty"`                 // The path that should be used by default to access the application
+	Attributes   map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`     // Additional endpoint attributes
+}
+
+type EndpointType string
+
+const (
+	TerminalEndpointType EndpointType = "terminal"
+	IdeEndpointType      EndpointType = "ide"
+)
+
+// Describes environment variable
+type Env struct {
+	Name      string        `json:"name" yaml:"name"`                               // The environment variable name
+	Value     string        `json:"value,omitempty" yaml:"value,omitempty"`         // The environment variable value
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty" yaml:"valueFrom,omitempty"` // Source for the environment variable's value. Cannot be used if value is not empty
+}
+
+// Describes a source for the value of an environment variable
+type EnvVarSource struct {
+	SecretKeyRef    *KeySelector `json:"secretKeyRef,omitempty" yaml:"secretKeyRef,omitempty"`       // Selects a key of a Secret in the workspace's namespace
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty" yaml:"configMapKeyRef,omitempty"` // Selects a key of a ConfigMap in the workspace's namespace
+}
+
+// Selects a key of a Secret or ConfigMap
+type KeySelector struct {
+	Name     string `json:"name" yaml:"name"`                             // The name of the Secret or ConfigMap
+	Key      string `json:"key" yaml:"key"`                               // The key to select
+	Optional bool   `json:"optional,omitempty" yaml:"optional,omitempty"` // Whether the Secret or ConfigMap and its key may be absent
+}
+
+// Describes a Secret or ConfigMap that should be mounted as files into a component
+type ObjectMount struct {
+	Name      string `json:"name" yaml:"name"`                             // The name of the Secret or ConfigMap in the workspace's namespace
+	MountPath string `json:"mountPath" yaml:"mountPath"`                   // The path where the Secret or ConfigMap should be mounted; each key is mounted as a file in this directory
+	Optional  bool   `json:"optional,omitempty" yaml:"optional,omitempty"` // Whether the Secret or ConfigMap may be absent
+}
+
+// Describe volume that should be mount to component
+type Volume struct {
+	ContainerPath string `json:"containerPath" yaml:"containerPath"`
+	Name          string `json:"name" yaml:"name"` // The volume name. If several components mount the same volume then they will reuse the volume; and will be able to access to the same files
+}
+
+type ComponentType string
+
+const (
+	CheEditor   ComponentType = "cheEditor"
+	ChePlugin   ComponentType = "chePlugin"
+	Dockerimage ComponentType = "dockerimage"
+	Kubernetes  ComponentType = "kubernetes"
+	Openshift   ComponentType = "openshift"
+)
+
+type CommandSpec struct {
+	Actions    []CommandActionSpec `json:"actions,omitempty" yaml:"actions,omitempty"`       // List of the actions of given command. Actions are run in order, unless parallel is set.
+	Commands   []string            `json:"commands,omitempty" yaml:"commands,omitempty"`     // Names of the commands run by a composite command. A command has either actions or commands.
+	Parallel   bool                `json:"parallel,omitempty" yaml:"parallel,omitempty"`     // Whether the actions or commands of given command are run in parallel rather than in order
+	Attributes map[string]string   `json:"attributes,omitempty" yaml:"attributes,omitempty"` // Additional command attributes
+	Name       string              `json:"name" yaml:"name"`                                 // Describes the name of the command. Should be unique per commands set.
+}
+
+type CommandActionSpec struct {
+	Command          string `json:"command,omitempty" yaml:"command,omitempty"`                   // The actual action command-line string
+	Component        string `json:"component,omitempty" yaml:"component,omitempty"`               // Describes component to which given action relates
+	Type             string `json:"type" yaml:"type"`                                             // Describes action type
+	Workdir          string `json:"workdir,omitempty" yaml:"workdir,omitempty"`                   // Working directory where the command should be executed
+	Reference        string `json:"reference,omitempty" yaml:"reference,omitempty"`               // Working directory where the command should be executed
+	ReferenceContent string `json:"referenceContent,omitempty" yaml:"referenceContent,omitempty"` // Working directory where the command should be executed
+}
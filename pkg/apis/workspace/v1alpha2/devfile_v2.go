@@ -0,0 +1,44 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package v1alpha2
+
+import "github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+
+// The devfile 2.0 schema is not versioned with the Workspace API, so v1alpha2 shares the types of v1alpha1. Workspaces
+// with a devfile 2.0 are converted between both versions without changes to the devfile.
+
+type DevfileV2Spec = v1alpha1.DevfileV2Spec
+type DevfileV2Parent = v1alpha1.DevfileV2Parent
+type KubernetesReference = v1alpha1.KubernetesReference
+type DevfileV2Project = v1alpha1.DevfileV2Project
+type DevfileV2ProjectSource = v1alpha1.DevfileV2ProjectSource
+type DevfileV2Component = v1alpha1.DevfileV2Component
+type DevfileV2ContainerComponent = v1alpha1.DevfileV2ContainerComponent
+type DevfileV2VolumeMount = v1alpha1.DevfileV2VolumeMount
+type DevfileV2VolumeComponent = v1alpha1.DevfileV2VolumeComponent
+type DevfileV2KubernetesComponent = v1alpha1.DevfileV2KubernetesComponent
+type DevfileV2PluginComponent = v1alpha1.DevfileV2PluginComponent
+type DevfileV2Endpoint = v1alpha1.DevfileV2Endpoint
+type EndpointExposure = v1alpha1.EndpointExposure
+type DevfileV2Command = v1alpha1.DevfileV2Command
+type DevfileV2ExecCommand = v1alpha1.DevfileV2ExecCommand
+type DevfileV2ApplyCommand = v1alpha1.DevfileV2ApplyCommand
+type DevfileV2CompositeCommand = v1alpha1.DevfileV2CompositeCommand
+type DevfileV2CommandGroup = v1alpha1.DevfileV2CommandGroup
+type DevfileEvents = v1alpha1.DevfileEvents
+
+const (
+	PublicEndpointExposure   = v1alpha1.PublicEndpointExposure
+	InternalEndpointExposure = v1alpha1.InternalEndpointExposure
+	NoneEndpointExposure     = v1alpha1.NoneEndpointExposure
+)
@@ -0,0 +1,16 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// Package v1alpha2 contains API Schema definitions for the workspace v1alpha2 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=workspace.che.eclipse.org
+package v1alpha2
@@ -0,0 +1,255 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// +build !ignore_autogenerated
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1alpha2
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"./pkg/apis/workspace/v1alpha2.Workspace":       schema_pkg_apis_workspace_v1alpha2_Workspace(ref),
+		"./pkg/apis/workspace/v1alpha2.WorkspaceSpec":   schema_pkg_apis_workspace_v1alpha2_WorkspaceSpec(ref),
+		"./pkg/apis/workspace/v1alpha2.WorkspaceStatus": schema_pkg_apis_workspace_v1alpha2_WorkspaceStatus(ref),
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha2_Workspace(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Workspace is the Schema for the workspaces API",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha2.WorkspaceSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("./pkg/apis/workspace/v1alpha2.WorkspaceStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha2.WorkspaceSpec", "./pkg/apis/workspace/v1alpha2.WorkspaceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha2_WorkspaceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceSpec defines the desired state of Workspace",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"started": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the workspace should be started or stopped",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"routingClass": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Routing class the defines how the workspace will be exposed to the external network",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"devfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workspace Structure defined in the Devfile format syntax. For more details see the Che 7 documentation: https://www.eclipse.org/che/docs/che-7/making-a-workspace-portable-using-a-devfile/ Exactly one of devfile and devfileV2 must be set, unless the workspace uses a template.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha2.DevfileSpec"),
+						},
+					},
+					"devfileV2": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workspace Structure defined in the Devfile 2.0 format syntax. Exactly one of devfile and devfileV2 must be set, unless the workspace uses a template.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.DevfileV2Spec"),
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reference to a WorkspaceTemplate whose devfile is used as the base of the workspace's devfile. Components, commands and projects of the workspace's devfile override those of the template with the same key (component alias, command name and project name) and extend the template's otherwise. The workspace's devfile must use the same format as the template's.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha1.KubernetesReference"),
+						},
+					},
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Node labels the workspace pod must match to be scheduled on a node. Merged with the defaults from the controller config, taking precedence over them.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations of the workspace pod, added to the defaults from the controller config",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Scheduling constraints of the workspace pod. Replaces the default affinity from the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"priorityClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the PriorityClass of the workspace pod. Defaults to the priority class from the controller config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"runtimeClassName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the RuntimeClass used to run the workspace pod. Defaults to the runtime class from the controller config.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"podSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Security context of the workspace pod. Fields that are set override the defaults from the controller config, subject to the security context policy of the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.PodSecurityContext"),
+						},
+					},
+					"containerSecurityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Security context of the workspace containers. Fields that are set override the defaults from the controller config, subject to the security context policy of the controller config.",
+							Ref:         ref("k8s.io/api/core/v1.SecurityContext"),
+						},
+					},
+					"terminationGracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration in seconds the workspace's containers are given to terminate gracefully when the workspace is stopped or restarted. Defaults to 1 second, or 30 seconds if the workspace has preStop commands.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"updatePolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Defines how changes that require restarting a running workspace are applied. With Immediate, the default, the workspace is restarted as soon as it changes. With OnConfirmation, changes to the spec of a running workspace are not applied to any of its objects until the workspace is annotated with org.eclipse.che.workspace/confirm-restart=true.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sharedWith": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Users and groups the workspace is shared with, in addition to its creator. They are allowed to exec into the workspace's containers and to access its endpoints secured by the openshift-oauth routing class. Only the workspace's creator may change it.",
+							Ref:         ref("./pkg/apis/workspace/v1alpha2.WorkspaceSharing"),
+						},
+					},
+				},
+				Required: []string{"started"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha1.DevfileV2Spec", "./pkg/apis/workspace/v1alpha1.KubernetesReference", "./pkg/apis/workspace/v1alpha2.DevfileSpec", "./pkg/apis/workspace/v1alpha2.WorkspaceSharing", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_pkg_apis_workspace_v1alpha2_WorkspaceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkspaceStatus defines the observed state of Workspace",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"workspaceId": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ideUrl": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions represent the latest available observations of an object's state",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("./pkg/apis/workspace/v1alpha2.WorkspaceCondition"),
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total resources requested by the workspace's containers, computed before the workspace deployment is created",
+							Ref:         ref("k8s.io/api/core/v1.ResourceRequirements"),
+						},
+					},
+				},
+				Required: []string{"workspaceId", "ideUrl"},
+			},
+		},
+		Dependencies: []string{
+			"./pkg/apis/workspace/v1alpha2.WorkspaceCondition", "k8s.io/api/core/v1.ResourceRequirements"},
+	}
+}
@@ -14,6 +14,7 @@ package common
 
 import (
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"strings"
 )
@@ -27,6 +28,59 @@ func EndpointName(endpointName string) string {
 	return name
 }
 
+// EndpointURLEnvVarName returns the name of the variable that can be used to reference an endpoint's URL in devfile
+// environment variables and commands.
+func EndpointURLEnvVarName(endpointName string) string {
+	name := strings.ReplaceAll(EndpointName(endpointName), "-", "_")
+	return fmt.Sprintf("CHE_ENDPOINT_%s_URL", strings.ToUpper(name))
+}
+
+// CommandActionName returns the name of the runtime command for an action of a devfile command. Commands with a
+// single action keep their name; the actions of other commands are numbered from 1.
+func CommandActionName(commandName string, actionIdx, actionCount int) string {
+	if actionCount <= 1 {
+		return commandName
+	}
+	return fmt.Sprintf("%s/%d", commandName, actionIdx+1)
+}
+
+// PluginContainerName returns the name of a container of a plugin whose devfile component has an alias. The first
+// container of the plugin is named after the alias, so that the component, its endpoints and its machine share the
+// same name; other containers are prefixed with the alias.
+func PluginContainerName(alias, containerName string, containerIdx int) string {
+	if alias == "" {
+		return containerName
+	}
+	if containerIdx == 0 {
+		return alias
+	}
+	return fmt.Sprintf("%s-%s", alias, containerName)
+}
+
+// UserNamespaceName returns the name of the namespace provisioned for a user, from a template in which '<username>' and
+// '<userid>' are replaced by the user's name and ID. Characters that are not valid in namespace names are replaced by
+// '-' and the name is truncated to 63 characters. If this changes the name, a hash of the user's name and ID is
+// appended, so that users whose names only differ in invalid characters or case get different namespaces.
+func UserNamespaceName(template, username, userId string) string {
+	name := strings.ReplaceAll(template, "<username>", username)
+	name = strings.ReplaceAll(name, "<userid>", userId)
+	sanitized := NonAlphaNumRegexp.ReplaceAllString(strings.ToLower(name), "-")
+	sanitized = strings.Trim(sanitized, "-")
+	if sanitized == name && len(name) <= 63 {
+		return name
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(username + "/" + userId))
+	suffix := fmt.Sprintf("%08x", hash.Sum32())
+	if len(sanitized) > 63-len(suffix)-1 {
+		sanitized = strings.TrimRight(sanitized[:63-len(suffix)-1], "-")
+	}
+	if sanitized == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", sanitized, suffix)
+}
+
 func ServiceName(workspaceId string) string {
 	return fmt.Sprintf("%s-%s", workspaceId, "service")
 }
@@ -59,10 +113,30 @@ func OAuthProxySecretName(workspaceId string) string {
 	return fmt.Sprintf("%s-%s", workspaceId, "proxy-tls")
 }
 
+func GitConfigMapName(workspaceId string) string {
+	return fmt.Sprintf("%s-%s", workspaceId, "gitconfig")
+}
+
+func GitCredentialsSecretName(workspaceId string) string {
+	return fmt.Sprintf("%s-%s", workspaceId, "git-credentials")
+}
+
 func DeploymentName(workspaceId string) string {
 	return workspaceId
 }
 
+// ObjectMountVolumeName returns the name of the volume used to mount a Secret or ConfigMap (according to kind) into a
+// component. The name is derived from a hash to stay unique and within the length limits for volume names.
+func ObjectMountVolumeName(kind, componentName, objectName string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(componentName + "/" + objectName))
+	return fmt.Sprintf("%s-%x", kind, hash.Sum32())
+}
+
 func ServingCertVolumeName(serviceName string) string {
 	return fmt.Sprintf("workspace-serving-cert-%s", serviceName)
 }
+
+func NetworkPolicyName(workspaceId string) string {
+	return fmt.Sprintf("%s-%s", workspaceId, "network-policy")
+}
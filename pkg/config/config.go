@@ -16,6 +16,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/che-incubator/che-workspace-operator/internal/cluster"
@@ -25,8 +26,10 @@ import (
 	routeV1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -106,6 +109,72 @@ func (wc *ControllerConfig) GetWebhooksEnabled() string {
 	return wc.GetPropertyOrDefault(webhooksEnabled, defaultWebhooksEnabled)
 }
 
+func (wc *ControllerConfig) GetWebhookServiceName() string {
+	return wc.GetPropertyOrDefault(webhookServiceName, defaultWebhookServiceName)
+}
+
+func (wc *ControllerConfig) GetWorkspaceAdminGroups() []string {
+	return splitList(wc.GetPropertyOrDefault(workspaceAdminGroups, defaultWorkspaceAdminGroups))
+}
+
+func (wc *ControllerConfig) GetWorkspaceNetworkPolicyEnabled() string {
+	return wc.GetPropertyOrDefault(workspaceNetworkPolicyEnabled, defaultWorkspaceNetworkPolicyEnabled)
+}
+
+// GetWorkspaceNetworkPolicyIngressNamespaces returns the selector of the namespaces from which public workspace
+// endpoints may be reached
+func (wc *ControllerConfig) GetWorkspaceNetworkPolicyIngressNamespaces() (*metav1.LabelSelector, error) {
+	defaultSelector := defaultWorkspaceNetworkPolicyIngressNamespaces
+	if wc.isOpenShift {
+		defaultSelector = defaultOpenShiftNetworkPolicyIngressNamespaces
+	}
+	selector := &metav1.LabelSelector{}
+	value := wc.GetPropertyOrDefault(workspaceNetworkPolicyIngressNamespaces, defaultSelector)
+	if err := unmarshalProperty(workspaceNetworkPolicyIngressNamespaces, value, selector); err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
+
+// GetWorkspaceEventsMaxCount returns the number of WorkspaceEvents that are kept for each workspace
+func (wc *ControllerConfig) GetWorkspaceEventsMaxCount() (int, error) {
+	value := wc.GetPropertyOrDefault(workspaceEventsMaxCount, defaultWorkspaceEventsMaxCount)
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("invalid value '%s' for property '%s': must be a non-negative integer", value, workspaceEventsMaxCount)
+	}
+	return count, nil
+}
+
+func (wc *ControllerConfig) GetImagePullerEnabled() string {
+	return wc.GetPropertyOrDefault(imagePullerEnabled, defaultImagePullerEnabled)
+}
+
+func (wc *ControllerConfig) GetImagePullerImages() []string {
+	return splitList(wc.GetPropertyOrDefault(imagePullerImages, ""))
+}
+
+func (wc *ControllerConfig) GetImagePullerAllowedWorkspaceImages() []string {
+	return splitList(wc.GetPropertyOrDefault(imagePullerAllowedWorkspaceImages, ""))
+}
+
+func (wc *ControllerConfig) GetImagePullerMaxImages() (int, error) {
+	value := wc.GetPropertyOrDefault(imagePullerMaxImages, defaultImagePullerMaxImages)
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("invalid value '%s' for property '%s': must be a positive integer", value, imagePullerMaxImages)
+	}
+	return count, nil
+}
+
+func (wc *ControllerConfig) GetImagePullerPullSecrets() []string {
+	return splitList(wc.GetPropertyOrDefault(imagePullerPullSecrets, ""))
+}
+
+func (wc *ControllerConfig) GetImagePullerSleepImage() string {
+	return wc.GetPropertyOrDefault(imagePullerSleepImage, defaultImagePullerSleepImage)
+}
+
 func (wc *ControllerConfig) GetProperty(name string) *string {
 	val, exists := wc.configMap.Data[name]
 	if exists {
@@ -126,13 +195,115 @@ func (wc *ControllerConfig) Validate() error {
 	if !wc.isOpenShift && wc.GetDefaultRoutingClass() == string(v1alpha1.WorkspaceRoutingOpenShiftOauth) {
 		return fmt.Errorf("controller appears to be running in non-OpenShift cluster, but default routing class is '%s'", v1alpha1.WorkspaceRoutingOpenShiftOauth)
 	}
-	return nil
+	resourceProperties := []string{
+		sidecarMemoryLimit, sidecarMemoryRequest, sidecarCpuLimit, sidecarCpuRequest,
+		containerMinMemory, containerMaxMemory, containerMinCpu, containerMaxCpu,
+	}
+	for _, property := range resourceProperties {
+		value := wc.GetProperty(property)
+		if value == nil || *value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(*value); err != nil {
+			return fmt.Errorf("invalid value '%s' for property '%s': %s", *value, property, err)
+		}
+	}
+	if err := wc.validateResourceDefaults(); err != nil {
+		return err
+	}
+	switch storageVersion := wc.GetWorkspaceStorageVersion(); storageVersion {
+	case "v1alpha1", "v1alpha2":
+	default:
+		return fmt.Errorf("invalid value '%s' for property '%s': supported versions are v1alpha1 and v1alpha2", storageVersion, workspaceStorageVersion)
+	}
+	if errs := validation.IsDNS1035Label(wc.GetWebhookServiceName()); len(errs) > 0 {
+		return fmt.Errorf("invalid value '%s' for property '%s': %s", wc.GetWebhookServiceName(), webhookServiceName, strings.Join(errs, ", "))
+	}
+	if _, err := wc.GetSchedulingDefaults(""); err != nil {
+		return err
+	}
+	if err := wc.validateNamespaceProvisioning(); err != nil {
+		return err
+	}
+	if _, err := wc.GetWorkspaceNetworkPolicyIngressNamespaces(); err != nil {
+		return err
+	}
+	if err := wc.validateQuota(); err != nil {
+		return err
+	}
+	if _, err := wc.GetWorkspaceEventsMaxCount(); err != nil {
+		return err
+	}
+	if _, err := wc.GetImagePullerMaxImages(); err != nil {
+		return err
+	}
+	return wc.validateSecurityContext()
+}
+
+func (wc *ControllerConfig) GetWorkspaceStorageVersion() string {
+	return wc.GetPropertyOrDefault(workspaceStorageVersion, defaultWorkspaceStorageVersion)
 }
 
 func (wc *ControllerConfig) GetWorkspaceIdleTimeout() string {
 	return wc.GetPropertyOrDefault(workspaceIdleTimeout, defaultWorkspaceIdleTimeout)
 }
 
+func (wc *ControllerConfig) GetSidecarDefaultMemoryLimit() string {
+	return wc.GetPropertyOrDefault(sidecarMemoryLimit, defaultSidecarMemoryLimit)
+}
+
+func (wc *ControllerConfig) GetSidecarDefaultMemoryRequest() string {
+	return wc.GetPropertyOrDefault(sidecarMemoryRequest, defaultSidecarMemoryRequest)
+}
+
+func (wc *ControllerConfig) GetSidecarDefaultCpuLimit() string {
+	return wc.GetPropertyOrDefault(sidecarCpuLimit, defaultSidecarCpuLimit)
+}
+
+func (wc *ControllerConfig) GetSidecarDefaultCpuRequest() string {
+	return wc.GetPropertyOrDefault(sidecarCpuRequest, defaultSidecarCpuRequest)
+}
+
+// validateResourceDefaults checks that the default resources of workspace containers are within the configured bounds,
+// as workspaces that use the defaults would fail to start otherwise
+func (wc *ControllerConfig) validateResourceDefaults() error {
+	defaults := []struct{ property, value, minProperty, maxProperty string }{
+		{sidecarMemoryLimit, wc.GetSidecarDefaultMemoryLimit(), containerMinMemory, containerMaxMemory},
+		{sidecarMemoryRequest, wc.GetSidecarDefaultMemoryRequest(), containerMinMemory, containerMaxMemory},
+		{sidecarCpuLimit, wc.GetSidecarDefaultCpuLimit(), containerMinCpu, containerMaxCpu},
+		{sidecarCpuRequest, wc.GetSidecarDefaultCpuRequest(), containerMinCpu, containerMaxCpu},
+	}
+	for _, d := range defaults {
+		if d.value == "" {
+			continue
+		}
+		quantity := resource.MustParse(d.value)
+		if min := wc.GetPropertyOrDefault(d.minProperty, ""); min != "" && quantity.Cmp(resource.MustParse(min)) < 0 {
+			return fmt.Errorf("invalid value '%s' for property '%s': less than '%s' (%s)", d.value, d.property, d.minProperty, min)
+		}
+		if max := wc.GetPropertyOrDefault(d.maxProperty, ""); max != "" && quantity.Cmp(resource.MustParse(max)) > 0 {
+			return fmt.Errorf("invalid value '%s' for property '%s': greater than '%s' (%s)", d.value, d.property, d.maxProperty, max)
+		}
+	}
+	return nil
+}
+
+func (wc *ControllerConfig) GetContainerMinMemory() string {
+	return wc.GetPropertyOrDefault(containerMinMemory, "")
+}
+
+func (wc *ControllerConfig) GetContainerMaxMemory() string {
+	return wc.GetPropertyOrDefault(containerMaxMemory, "")
+}
+
+func (wc *ControllerConfig) GetContainerMinCpu() string {
+	return wc.GetPropertyOrDefault(containerMinCpu, "")
+}
+
+func (wc *ControllerConfig) GetContainerMaxCpu() string {
+	return wc.GetPropertyOrDefault(containerMaxCpu, "")
+}
+
 func updateConfigMap(client client.Client, meta metav1.Object, obj runtime.Object) {
 	if meta.GetNamespace() != ConfigMapReference.Namespace ||
 		meta.GetName() != ConfigMapReference.Name {
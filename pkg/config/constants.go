@@ -43,12 +43,71 @@ const (
 	// WorkspaceCreatorLabel is the label key for storing the UID of the user who created the workspace
 	WorkspaceCreatorLabel = "org.eclipse.che.workspace/creator"
 
+	// WorkspaceCreatorUsernameAnnotation is the annotation key for storing the name of the user who created the
+	// workspace, which is granted access to the workspace's endpoints along with the users it is shared with
+	WorkspaceCreatorUsernameAnnotation = "org.eclipse.che.workspace/creator-username"
+
 	// WorkspaceImmutableAnnotation marks a workspace as 'immutable' if 'true'
 	WorkspaceImmutableAnnotation = "org.eclipse.che.workspace/immutable"
 
+	// ImagePullerName is the name of the ImagePuller and of the DaemonSet that caches workspace images on every node
+	ImagePullerName = "che-workspace-image-puller"
+
+	// ImagePullerLabel is the label key that identifies the pods of the image puller
+	ImagePullerLabel = "org.eclipse.che.workspace/image-puller"
+
+	// WorkspaceConfirmRestartAnnotation confirms a pending restart of a workspace with the OnConfirmation update policy
+	// if 'true'. The annotation is removed once the workspace's changes are applied.
+	WorkspaceConfirmRestartAnnotation = "org.eclipse.che.workspace/confirm-restart"
+
+	// WorkspaceAppliedGenerationAnnotation records on a workspace deployment the generation of the workspace whose spec
+	// was last applied to it
+	WorkspaceAppliedGenerationAnnotation = "org.eclipse.che.workspace/applied-generation"
+
 	// WorkspaceDiscoverableServiceAnnotation marks a service in a workspace as created for a discoverable endpoint,
 	// as opposed to a service created to support the workspace itself.
 	WorkspaceDiscoverableServiceAnnotation = "org.eclipse.che.workspace/discoverable-service"
+
+	// WorkspaceMountLabel marks a Secret or ConfigMap in a workspace's namespace to be mounted into all workspaces in
+	// that namespace if 'true'
+	WorkspaceMountLabel = "org.eclipse.che.workspace/mount-to-workspaces"
+
+	// WorkspaceMountPathAnnotation is the annotation key for the directory where an automounted Secret or ConfigMap
+	// is mounted. Defaults to /etc/secret/<name> for Secrets and /etc/config/<name> for ConfigMaps.
+	WorkspaceMountPathAnnotation = "org.eclipse.che.workspace/mount-path"
+
+	// WorkspaceMountAsAnnotation is the annotation key that defines how an automounted Secret or ConfigMap is provided
+	// to workspace containers: 'file' (default) mounts it as files, 'env' adds its keys as environment variables.
+	WorkspaceMountAsAnnotation = "org.eclipse.che.workspace/mount-as"
+
+	// WorkspaceGitUserNameAnnotation is the annotation key on a workspace for the name used in the workspace's git config
+	WorkspaceGitUserNameAnnotation = "org.eclipse.che.workspace/git-user-name"
+
+	// WorkspaceGitUserEmailAnnotation is the annotation key on a workspace for the email used in the workspace's git config
+	WorkspaceGitUserEmailAnnotation = "org.eclipse.che.workspace/git-user-email"
+
+	// WorkspaceGitCredentialLabel marks a Secret as containing git credentials if 'true'. The Secret's 'credentials'
+	// key should be in the format of the git credential store, i.e. one https://<user>:<token>@<host> URL per line.
+	WorkspaceGitCredentialLabel = "org.eclipse.che.workspace/git-credential"
+
+	// WorkspaceGitSSHKeyLabel marks a Secret as containing an SSH key for git if 'true'. The Secret should store the
+	// private key under 'ssh-privatekey' and can optionally store entries for ~/.ssh/known_hosts under 'known_hosts'.
+	WorkspaceGitSSHKeyLabel = "org.eclipse.che.workspace/git-ssh-key"
+
+	// WorkspaceGitConfigHashAnnotation is the annotation key on the workspace pod template that stores a hash of the
+	// workspace's git configuration, so that workspaces are restarted when it changes.
+	WorkspaceGitConfigHashAnnotation = "org.eclipse.che.workspace/git-config-hash"
+
+	// UserNamespaceLabel marks a namespace as provisioned for a user's workspaces if 'true'
+	UserNamespaceLabel = "org.eclipse.che.workspace/user-namespace"
+
+	// UserNamespaceUsernameAnnotation is the annotation key on a provisioned namespace for the name of the user it is
+	// provisioned for
+	UserNamespaceUsernameAnnotation = "org.eclipse.che.workspace/namespace-username"
+
+	// WorkspaceAutoMountHashAnnotation is the annotation key on the workspace pod template that stores a hash of the
+	// automounted Secrets and ConfigMaps, so that workspaces are restarted when they change.
+	WorkspaceAutoMountHashAnnotation = "org.eclipse.che.workspace/automount-hash"
 )
 
 // Constants for che-rest-apis
@@ -86,6 +145,19 @@ const (
 	// Workspace command attributes that indicates with which component it is associated. */
 	ComponentAliasCommandAttribute = "componentAlias"
 
+	// Command attribute which stores the human-readable name of a command converted from a devfile 2.0
+	CommandLabelAttribute = "label"
+
+	// Command attribute which stores the group (build, run, test or debug) of a command converted from a devfile 2.0
+	CommandGroupAttribute = "group"
+
+	// Command attribute which indicates that a command converted from a devfile 2.0 is the default one of its group
+	CommandIsDefaultAttribute = "isDefault"
+
+	// Type of the runtime commands that run other commands, either composite devfile commands or devfile commands
+	// with several actions
+	CompositeCommandType = "composite"
+
 	// RestAPIsRuntimeVolumePathis the path where workspace information is mounted in che-rest-apis
 	RestAPIsRuntimeVolumePath = "/workspace/"
 
@@ -121,6 +193,10 @@ const (
 
 	// Policy on webhook failure
 	MutateWebhookFailurePolicy = v1beta1.Fail
+
+	// WebhookConfigurationLabel marks the webhook configurations that are managed by the controller, so that stale
+	// configurations left by previous installs can be found and removed
+	WebhookConfigurationLabel = "org.eclipse.che.workspace/webhook-configuration"
 )
 
 // constants for workspace controller
@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func (wc *ControllerConfig) GetNamespaceProvisioningEnabled() string {
+	return wc.GetPropertyOrDefault(namespaceProvisioningEnabled, defaultNamespaceProvisioningEnabled)
+}
+
+func (wc *ControllerConfig) GetNamespaceTemplate() string {
+	return wc.GetPropertyOrDefault(namespaceTemplate, defaultNamespaceTemplate)
+}
+
+func (wc *ControllerConfig) GetNamespaceUserClusterRole() string {
+	return wc.GetPropertyOrDefault(namespaceUserClusterRole, defaultNamespaceUserClusterRole)
+}
+
+// GetNamespaceLabels returns the labels of provisioned namespaces, including the label that marks them as provisioned
+func (wc *ControllerConfig) GetNamespaceLabels() (map[string]string, error) {
+	labels := map[string]string{}
+	if value := wc.GetPropertyOrDefault(namespaceLabels, ""); value != "" {
+		if err := unmarshalProperty(namespaceLabels, value, &labels); err != nil {
+			return nil, err
+		}
+	}
+	labels[UserNamespaceLabel] = "true"
+	return labels, nil
+}
+
+// GetNamespaceResourceQuota returns the spec of the ResourceQuota of provisioned namespaces, or nil if it is disabled
+func (wc *ControllerConfig) GetNamespaceResourceQuota() (*corev1.ResourceQuotaSpec, error) {
+	value := wc.GetPropertyOrDefault(namespaceResourceQuota, defaultNamespaceResourceQuota)
+	if value == "" {
+		return nil, nil
+	}
+	spec := &corev1.ResourceQuotaSpec{}
+	if err := unmarshalProperty(namespaceResourceQuota, value, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// GetNamespaceLimitRange returns the spec of the LimitRange of provisioned namespaces, or nil if it is disabled
+func (wc *ControllerConfig) GetNamespaceLimitRange() (*corev1.LimitRangeSpec, error) {
+	value := wc.GetPropertyOrDefault(namespaceLimitRange, defaultNamespaceLimitRange)
+	if value == "" {
+		return nil, nil
+	}
+	spec := &corev1.LimitRangeSpec{}
+	if err := unmarshalProperty(namespaceLimitRange, value, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// GetNamespaceNetworkPolicy returns the spec of the NetworkPolicy of provisioned namespaces, or nil if it is disabled
+func (wc *ControllerConfig) GetNamespaceNetworkPolicy() (*networkingv1.NetworkPolicySpec, error) {
+	value := wc.GetPropertyOrDefault(namespaceNetworkPolicy, defaultNamespaceNetworkPolicy)
+	if value == "" {
+		return nil, nil
+	}
+	spec := &networkingv1.NetworkPolicySpec{}
+	if err := unmarshalProperty(namespaceNetworkPolicy, value, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (wc *ControllerConfig) validateNamespaceProvisioning() error {
+	if _, err := wc.GetNamespaceLabels(); err != nil {
+		return err
+	}
+	if _, err := wc.GetNamespaceResourceQuota(); err != nil {
+		return err
+	}
+	if _, err := wc.GetNamespaceLimitRange(); err != nil {
+		return err
+	}
+	_, err := wc.GetNamespaceNetworkPolicy()
+	return err
+}
+
+func unmarshalProperty(name, value string, v interface{}) error {
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("invalid value for property '%s': %s", name, err)
+	}
+	return nil
+}
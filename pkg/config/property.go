@@ -39,7 +39,172 @@ const (
 
 	webhooksEnabled        = "che.webhooks.enabled"
 	defaultWebhooksEnabled = "true"
+	// webhookServiceName is the name of the service exposing the webhook server, which is created in the controller's
+	// namespace and selects the pods of the controller's deployment
+	webhookServiceName        = "che.webhooks.service_name"
+	defaultWebhookServiceName = "workspace-controller"
 
 	workspaceIdleTimeout        = "che.workspace.idle_timeout"
 	defaultWorkspaceIdleTimeout = "15m"
+
+	// sidecarMemoryLimit, sidecarMemoryRequest, sidecarCpuLimit and sidecarCpuRequest define the resources used for
+	// workspace containers that do not specify them explicitly. An empty value means the resource is not set.
+	sidecarMemoryLimit          = "che.workspace.sidecar.default_memory_limit"
+	defaultSidecarMemoryLimit   = SidecarDefaultMemoryLimit
+	sidecarMemoryRequest        = "che.workspace.sidecar.default_memory_request"
+	defaultSidecarMemoryRequest = "64Mi"
+	sidecarCpuLimit             = "che.workspace.sidecar.default_cpu_limit"
+	defaultSidecarCpuLimit      = ""
+	sidecarCpuRequest           = "che.workspace.sidecar.default_cpu_request"
+	defaultSidecarCpuRequest    = ""
+
+	// containerMinMemory, containerMaxMemory, containerMinCpu and containerMaxCpu bound the requests and limits of
+	// workspace containers. Workspaces with values outside of the bounds fail to start; an empty value disables the
+	// bound.
+	containerMinMemory = "che.workspace.container.min_memory"
+	containerMaxMemory = "che.workspace.container.max_memory"
+	containerMinCpu    = "che.workspace.container.min_cpu"
+	containerMaxCpu    = "che.workspace.container.max_cpu"
+
+	// workspaceStorageVersion defines the version of the Workspace API used to store workspaces in the cluster.
+	// Changing it migrates existing workspaces to the new version on startup; this requires webhooks to be enabled.
+	workspaceStorageVersion        = "che.workspace.storage_version"
+	defaultWorkspaceStorageVersion = "v1alpha1"
+
+	// schedulingDefaults defines the default scheduling settings of workspace pods, as a JSON object with the optional
+	// fields nodeSelector, tolerations, affinity, priorityClassName and runtimeClassName.
+	schedulingDefaults = "che.workspace.scheduling.defaults"
+	// namespaceSchedulingDefaults overrides schedulingDefaults for workspaces in given namespaces, as a JSON object
+	// mapping namespace names to scheduling settings. Fields that are set for a namespace replace the cluster defaults.
+	namespaceSchedulingDefaults = "che.workspace.scheduling.namespace_defaults"
+
+	// allowedNodeSelectorKeys, allowedTolerationKeys, allowedPriorityClasses and allowedRuntimeClasses are comma-separated
+	// lists of the node label keys, taint keys, priority classes and runtime classes that users may specify in
+	// workspaces; '*' allows any value. allowAffinity defines whether users may specify affinity. Values that are not
+	// allowed are rejected by the webhook server; defaults from the controller config are not restricted.
+	allowedNodeSelectorKeys = "che.workspace.scheduling.allowed_node_selector_keys"
+	allowedTolerationKeys   = "che.workspace.scheduling.allowed_toleration_keys"
+	allowedPriorityClasses  = "che.workspace.scheduling.allowed_priority_classes"
+	allowedRuntimeClasses   = "che.workspace.scheduling.allowed_runtime_classes"
+	allowAffinity           = "che.workspace.scheduling.allow_affinity"
+	defaultAllowAffinity    = "false"
+
+	// podSecurityContext and containerSecurityContext define the security contexts of workspace pods and containers,
+	// as JSON-encoded corev1.PodSecurityContext and corev1.SecurityContext. The defaults follow the restricted Pod
+	// Security Standard except for the seccomp profile (see seccompProfile); on OpenShift, the user ID is assigned by
+	// the security context constraints.
+	podSecurityContext                 = "che.workspace.pod_security_context"
+	defaultPodSecurityContext          = `{"runAsUser":1234,"fsGroup":1234,"runAsNonRoot":true}`
+	defaultOpenShiftPodSecurityContext = `{"runAsNonRoot":true}`
+	containerSecurityContext           = "che.workspace.container_security_context"
+	defaultContainerSecurityContext    = `{"allowPrivilegeEscalation":false,"capabilities":{"drop":["ALL"]}}`
+	// seccompProfile defines the seccomp profile of workspace pods; an empty value leaves it unset. It is not set by
+	// default on OpenShift, where it is assigned by the security context constraints. As the API version in use has no
+	// seccompProfile field, the profile is set with the deprecated seccomp.security.alpha.kubernetes.io/pod annotation,
+	// which does not satisfy the restricted Pod Security Standard, so that workspace pods are rejected in namespaces
+	// enforcing it.
+	seccompProfile        = "che.workspace.seccomp_profile"
+	defaultSeccompProfile = "runtime/default"
+	// securityContextPolicy defines which security context overrides users may specify in workspaces: 'deny' rejects
+	// any override, 'restricted' only allows overrides that are compatible with the restricted Pod Security Standard
+	// and 'any' allows all overrides.
+	securityContextPolicy        = "che.workspace.security_context.policy"
+	defaultSecurityContextPolicy = SecurityContextPolicyDeny
+
+	// workspaceAdminGroups is a comma-separated list of the groups whose members may change objects owned by workspaces,
+	// such as workspace deployments, services and components, which can otherwise only be changed by the controller
+	// and the built-in Kubernetes controllers.
+	workspaceAdminGroups        = "che.workspace.admin_groups"
+	defaultWorkspaceAdminGroups = "system:masters,system:cluster-admins"
+
+	// namespaceProvisioningEnabled defines whether a namespace is provisioned for each user, in which the user's
+	// workspaces must be created. The namespace is named after namespaceTemplate, where '<username>' and '<userid>' are
+	// replaced by the user's name and ID. If the resulting name is not a valid namespace name, it is sanitized and a hash
+	// of the user's name and ID is appended to keep the namespaces of different users distinct.
+	namespaceProvisioningEnabled        = "che.workspace.namespace.provisioning.enabled"
+	defaultNamespaceProvisioningEnabled = "false"
+	namespaceTemplate                   = "che.workspace.namespace.template"
+	defaultNamespaceTemplate            = "<username>-che"
+	// namespaceLabels defines additional labels of provisioned namespaces, as a JSON object
+	namespaceLabels = "che.workspace.namespace.labels"
+	// namespaceResourceQuota, namespaceLimitRange and namespaceNetworkPolicy define the ResourceQuota, LimitRange and
+	// NetworkPolicy created in provisioned namespaces, as JSON-encoded specs; an empty value disables the object. The
+	// default NetworkPolicy only allows traffic from the same namespace and from namespaces that are not provisioned
+	// for users, such as the namespaces of ingress controllers. It does not select workspace pods, which are isolated
+	// by the NetworkPolicy of their workspace, as NetworkPolicies that select the same pod are combined.
+	namespaceResourceQuota        = "che.workspace.namespace.resource_quota"
+	defaultNamespaceResourceQuota = `{"hard":{"limits.memory":"16Gi","requests.storage":"20Gi"}}`
+	namespaceLimitRange           = "che.workspace.namespace.limit_range"
+	defaultNamespaceLimitRange    = `{"limits":[{"type":"Container","default":{"memory":"1Gi"},"defaultRequest":{"memory":"64Mi"}}]}`
+	namespaceNetworkPolicy        = "che.workspace.namespace.network_policy"
+	defaultNamespaceNetworkPolicy = `{"podSelector":{"matchExpressions":[{"key":"` + WorkspaceIDLabel + `","operator":"DoesNotExist"}]},` +
+		`"policyTypes":["Ingress"],"ingress":[{"from":[{"podSelector":{}},` +
+		`{"namespaceSelector":{"matchExpressions":[{"key":"` + UserNamespaceLabel + `","operator":"DoesNotExist"}]}}]}]}`
+	// namespaceUserClusterRole is the ClusterRole granted to users in their namespace. The controller may only bind the
+	// ClusterRole listed in deploy/role.yaml, which has to be updated when this property is changed
+	namespaceUserClusterRole        = "che.workspace.namespace.user_cluster_role"
+	defaultNamespaceUserClusterRole = "edit"
+
+	// workspaceNetworkPolicyEnabled defines whether a NetworkPolicy is provisioned for each workspace, which only
+	// allows traffic to the workspace pod from the pod itself, from the ingress controllers to public endpoints and from
+	// other workspaces in the namespace to discoverable endpoints that are shared with the 'shared' endpoint attribute.
+	// It is disabled by default, as other clients of workspace endpoints are denied, e.g. routers using the host
+	// network on OpenShift, which are not matched by the default ingress namespace selector, or the Che server, which
+	// connects to workspaces directly with the cluster routing class.
+	workspaceNetworkPolicyEnabled        = "che.workspace.network_policy.enabled"
+	defaultWorkspaceNetworkPolicyEnabled = "false"
+	// workspaceNetworkPolicyIngressNamespaces selects the namespaces of ingress controllers or routers, as a JSON-encoded
+	// label selector. By default, the router namespaces are selected on OpenShift; on Kubernetes, public endpoints are
+	// reachable from all namespaces, as the namespace of the ingress controller is not known.
+	workspaceNetworkPolicyIngressNamespaces        = "che.workspace.network_policy.ingress_namespace_selector"
+	defaultWorkspaceNetworkPolicyIngressNamespaces = `{}`
+	defaultOpenShiftNetworkPolicyIngressNamespaces = `{"matchLabels":{"network.openshift.io/policy-group":"ingress"}}`
+
+	// quotaMaxRunningPerUser and quotaMaxRunningPerNamespace limit the number of running workspaces of each user (as
+	// identified by the workspace creator label) and in each namespace; quotaMaxMemoryPerUser and
+	// quotaMaxMemoryPerNamespace limit the total memory of their running workspaces. An empty value disables the limit.
+	// Workspaces of a user are counted in all namespaces.
+	quotaMaxRunningPerUser      = "che.workspace.quota.max_running_per_user"
+	quotaMaxRunningPerNamespace = "che.workspace.quota.max_running_per_namespace"
+	quotaMaxMemoryPerUser       = "che.workspace.quota.max_memory_per_user"
+	quotaMaxMemoryPerNamespace  = "che.workspace.quota.max_memory_per_namespace"
+	// namespaceQuotas overrides the quotas for workspaces in given namespaces, as a JSON object mapping namespace names
+	// to quotas with the optional fields maxRunningPerUser, maxRunningPerNamespace, maxMemoryPerUser and
+	// maxMemoryPerNamespace. Fields that are set for a namespace replace the cluster-wide quotas.
+	namespaceQuotas = "che.workspace.quota.namespace_overrides"
+	// quotaPolicy defines how workspace starts that exceed quotas are handled: 'deny' rejects them, while 'queue' keeps
+	// the workspace starting until enough workspaces are stopped.
+	quotaPolicy        = "che.workspace.quota.policy"
+	defaultQuotaPolicy = QuotaPolicyDeny
+
+	// workspaceEventsMaxCount is the number of WorkspaceEvents that are kept for each workspace to record who requested
+	// changes to it; older events are deleted. A value of 0 disables WorkspaceEvents, while requested changes are still
+	// logged by the webhook server. Events are recorded once the webhook server allows a change, so a change that is
+	// rejected afterwards, e.g. by another admission webhook or because of a conflict, is recorded although it was never
+	// applied.
+	workspaceEventsMaxCount        = "che.workspace.events.max_count"
+	defaultWorkspaceEventsMaxCount = "50"
+
+	// imagePullerEnabled defines whether the images used by workspaces are cached on every node by a DaemonSet
+	imagePullerEnabled        = "che.image_puller.enabled"
+	defaultImagePullerEnabled = "false"
+	// imagePullerImages is a comma-separated list of images cached by the image puller in addition to the images of
+	// the internal registry and the allowed images of running workspaces
+	imagePullerImages = "che.image_puller.images"
+	// imagePullerAllowedWorkspaceImages is a comma-separated list of the images of running workspaces that are cached
+	// by the image puller; entries ending with '*' match images by prefix. As devfiles may reference any image, other
+	// images of workspaces are only cached if they are provided by the internal registry.
+	imagePullerAllowedWorkspaceImages = "che.image_puller.allowed_workspace_images"
+	// imagePullerMaxImages is the maximum number of images cached by the image puller, as each image adds a container
+	// to the image puller's pod on every node. The images of the ImagePuller's spec and of the controller config are
+	// cached first, followed by the images of the internal registry and of running workspaces.
+	imagePullerMaxImages        = "che.image_puller.max_images"
+	defaultImagePullerMaxImages = "20"
+	// imagePullerPullSecrets is a comma-separated list of the Secrets in the controller's namespace that are used to
+	// pull the cached images
+	imagePullerPullSecrets = "che.image_puller.image_pull_secrets"
+	// imagePullerSleepImage is the image that provides the statically linked busybox binary used to keep the image
+	// puller's containers running
+	imagePullerSleepImage        = "che.image_puller.sleep_image"
+	defaultImagePullerSleepImage = "docker.io/library/busybox:1.31.1"
 )
@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Policies for workspace starts that exceed quotas
+const (
+	QuotaPolicyDeny  = "deny"
+	QuotaPolicyQueue = "queue"
+)
+
+// WorkspaceQuota describes the limits of running workspaces that are configured in the controller config. Unset
+// fields are not limited.
+type WorkspaceQuota struct {
+	MaxRunningPerUser      *int               `json:"maxRunningPerUser,omitempty"`
+	MaxRunningPerNamespace *int               `json:"maxRunningPerNamespace,omitempty"`
+	MaxMemoryPerUser       *resource.Quantity `json:"maxMemoryPerUser,omitempty"`
+	MaxMemoryPerNamespace  *resource.Quantity `json:"maxMemoryPerNamespace,omitempty"`
+}
+
+// GetWorkspaceQuota returns the quotas of workspaces in a namespace. Quotas configured for the namespace replace the
+// cluster-wide quotas.
+func (wc *ControllerConfig) GetWorkspaceQuota(namespace string) (*WorkspaceQuota, error) {
+	quota := &WorkspaceQuota{}
+	var err error
+	if quota.MaxRunningPerUser, err = wc.getCountProperty(quotaMaxRunningPerUser); err != nil {
+		return nil, err
+	}
+	if quota.MaxRunningPerNamespace, err = wc.getCountProperty(quotaMaxRunningPerNamespace); err != nil {
+		return nil, err
+	}
+	if quota.MaxMemoryPerUser, err = wc.getQuantityProperty(quotaMaxMemoryPerUser); err != nil {
+		return nil, err
+	}
+	if quota.MaxMemoryPerNamespace, err = wc.getQuantityProperty(quotaMaxMemoryPerNamespace); err != nil {
+		return nil, err
+	}
+
+	value := wc.GetPropertyOrDefault(namespaceQuotas, "")
+	if value == "" {
+		return quota, nil
+	}
+	namespaceOverrides := map[string]WorkspaceQuota{}
+	if err := unmarshalProperty(namespaceQuotas, value, &namespaceOverrides); err != nil {
+		return nil, err
+	}
+	overrides, ok := namespaceOverrides[namespace]
+	if !ok {
+		return quota, nil
+	}
+	if overrides.MaxRunningPerUser != nil {
+		quota.MaxRunningPerUser = overrides.MaxRunningPerUser
+	}
+	if overrides.MaxRunningPerNamespace != nil {
+		quota.MaxRunningPerNamespace = overrides.MaxRunningPerNamespace
+	}
+	if overrides.MaxMemoryPerUser != nil {
+		quota.MaxMemoryPerUser = overrides.MaxMemoryPerUser
+	}
+	if overrides.MaxMemoryPerNamespace != nil {
+		quota.MaxMemoryPerNamespace = overrides.MaxMemoryPerNamespace
+	}
+	return quota, nil
+}
+
+func (wc *ControllerConfig) GetQuotaPolicy() string {
+	return wc.GetPropertyOrDefault(quotaPolicy, defaultQuotaPolicy)
+}
+
+func (wc *ControllerConfig) getCountProperty(name string) (*int, error) {
+	value := wc.GetPropertyOrDefault(name, "")
+	if value == "" {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid value '%s' for property '%s': must be a non-negative integer", value, name)
+	}
+	return &count, nil
+}
+
+func (wc *ControllerConfig) getQuantityProperty(name string) (*resource.Quantity, error) {
+	value := wc.GetPropertyOrDefault(name, "")
+	if value == "" {
+		return nil, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value '%s' for property '%s': %s", value, name, err)
+	}
+	return &quantity, nil
+}
+
+func (wc *ControllerConfig) validateQuota() error {
+	if _, err := wc.GetWorkspaceQuota(""); err != nil {
+		return err
+	}
+	switch policy := wc.GetQuotaPolicy(); policy {
+	case QuotaPolicyDeny, QuotaPolicyQueue:
+		return nil
+	default:
+		return fmt.Errorf("invalid value '%s' for property '%s': supported policies are %s and %s", policy,
+			quotaPolicy, QuotaPolicyDeny, QuotaPolicyQueue)
+	}
+}
@@ -0,0 +1,109 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkspaceScheduling describes scheduling settings of workspace pods that are configured in the controller config
+type WorkspaceScheduling struct {
+	NodeSelector      map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
+	Affinity          *corev1.Affinity    `json:"affinity,omitempty"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	RuntimeClassName  *string             `json:"runtimeClassName,omitempty"`
+}
+
+// GetSchedulingDefaults returns the default scheduling settings of workspace pods in a namespace. Settings configured
+// for the namespace replace the cluster defaults.
+func (wc *ControllerConfig) GetSchedulingDefaults(namespace string) (*WorkspaceScheduling, error) {
+	defaults := &WorkspaceScheduling{}
+	if value := wc.GetProperty(schedulingDefaults); value != nil && *value != "" {
+		if err := json.Unmarshal([]byte(*value), defaults); err != nil {
+			return nil, fmt.Errorf("invalid value for property '%s': %s", schedulingDefaults, err)
+		}
+	}
+	value := wc.GetProperty(namespaceSchedulingDefaults)
+	if value == nil || *value == "" {
+		return defaults, nil
+	}
+	namespaceDefaults := map[string]WorkspaceScheduling{}
+	if err := json.Unmarshal([]byte(*value), &namespaceDefaults); err != nil {
+		return nil, fmt.Errorf("invalid value for property '%s': %s", namespaceSchedulingDefaults, err)
+	}
+	overrides, ok := namespaceDefaults[namespace]
+	if !ok {
+		return defaults, nil
+	}
+	if overrides.NodeSelector != nil {
+		defaults.NodeSelector = overrides.NodeSelector
+	}
+	if overrides.Tolerations != nil {
+		defaults.Tolerations = overrides.Tolerations
+	}
+	if overrides.Affinity != nil {
+		defaults.Affinity = overrides.Affinity
+	}
+	if overrides.PriorityClassName != "" {
+		defaults.PriorityClassName = overrides.PriorityClassName
+	}
+	if overrides.RuntimeClassName != nil {
+		defaults.RuntimeClassName = overrides.RuntimeClassName
+	}
+	return defaults, nil
+}
+
+func (wc *ControllerConfig) GetAllowedNodeSelectorKeys() []string {
+	return splitList(wc.GetPropertyOrDefault(allowedNodeSelectorKeys, ""))
+}
+
+func (wc *ControllerConfig) GetAllowedTolerationKeys() []string {
+	return splitList(wc.GetPropertyOrDefault(allowedTolerationKeys, ""))
+}
+
+func (wc *ControllerConfig) GetAllowedPriorityClasses() []string {
+	return splitList(wc.GetPropertyOrDefault(allowedPriorityClasses, ""))
+}
+
+func (wc *ControllerConfig) GetAllowedRuntimeClasses() []string {
+	return splitList(wc.GetPropertyOrDefault(allowedRuntimeClasses, ""))
+}
+
+func (wc *ControllerConfig) GetAllowAffinity() string {
+	return wc.GetPropertyOrDefault(allowAffinity, defaultAllowAffinity)
+}
+
+// IsAllowed returns whether value is in an allow-list returned by the controller config
+func IsAllowed(allowList []string, value string) bool {
+	for _, allowed := range allowList {
+		if allowed == "*" || allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
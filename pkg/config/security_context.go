@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Policies for the security context overrides users may specify in workspaces
+const (
+	SecurityContextPolicyDeny       = "deny"
+	SecurityContextPolicyRestricted = "restricted"
+	SecurityContextPolicyAny        = "any"
+)
+
+// GetPodSecurityContext returns the default security context of workspace pods
+func (wc *ControllerConfig) GetPodSecurityContext() (*corev1.PodSecurityContext, error) {
+	defaultValue := defaultPodSecurityContext
+	if wc.IsOpenShift() {
+		defaultValue = defaultOpenShiftPodSecurityContext
+	}
+	securityContext := &corev1.PodSecurityContext{}
+	if err := json.Unmarshal([]byte(wc.GetPropertyOrDefault(podSecurityContext, defaultValue)), securityContext); err != nil {
+		return nil, fmt.Errorf("invalid value for property '%s': %s", podSecurityContext, err)
+	}
+	return securityContext, nil
+}
+
+// GetContainerSecurityContext returns the default security context of workspace containers
+func (wc *ControllerConfig) GetContainerSecurityContext() (*corev1.SecurityContext, error) {
+	securityContext := &corev1.SecurityContext{}
+	if err := json.Unmarshal([]byte(wc.GetPropertyOrDefault(containerSecurityContext, defaultContainerSecurityContext)), securityContext); err != nil {
+		return nil, fmt.Errorf("invalid value for property '%s': %s", containerSecurityContext, err)
+	}
+	return securityContext, nil
+}
+
+func (wc *ControllerConfig) GetSeccompProfile() string {
+	if wc.IsOpenShift() {
+		return wc.GetPropertyOrDefault(seccompProfile, "")
+	}
+	return wc.GetPropertyOrDefault(seccompProfile, defaultSeccompProfile)
+}
+
+func (wc *ControllerConfig) GetSecurityContextPolicy() string {
+	return wc.GetPropertyOrDefault(securityContextPolicy, defaultSecurityContextPolicy)
+}
+
+func (wc *ControllerConfig) validateSecurityContext() error {
+	if _, err := wc.GetPodSecurityContext(); err != nil {
+		return err
+	}
+	if _, err := wc.GetContainerSecurityContext(); err != nil {
+		return err
+	}
+	switch policy := wc.GetSecurityContextPolicy(); policy {
+	case SecurityContextPolicyDeny, SecurityContextPolicyRestricted, SecurityContextPolicyAny:
+		return nil
+	default:
+		return fmt.Errorf("invalid value '%s' for property '%s': supported policies are %s, %s and %s", policy,
+			securityContextPolicy, SecurityContextPolicyDeny, SecurityContextPolicyRestricted, SecurityContextPolicyAny)
+	}
+}
@@ -17,10 +17,13 @@ import (
 	"fmt"
 
 	"github.com/che-incubator/che-workspace-operator/pkg/adaptor"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/che-incubator/che-workspace-operator/pkg/controller/workspace/env"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -114,14 +117,19 @@ func (r *ReconcileComponent) Reconcile(request reconcile.Request) (reconcile.Res
 
 	commands := instance.Spec.Commands
 
-	dockerimageComponents, err := adaptor.AdaptDockerimageComponents(instance.Spec.WorkspaceId, dockerimageDevfileComponents, commands)
+	commonEnv, err := r.getCommonEnv(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	dockerimageComponents, err := adaptor.AdaptDockerimageComponents(instance.Spec.WorkspaceId, dockerimageDevfileComponents, commands, instance.Spec.Events, commonEnv)
 	if err != nil {
 		reqLogger.Info("Failed to adapt dockerimage components")
 		return reconcile.Result{}, err
 	}
 	components = append(components, dockerimageComponents...)
 
-	pluginComponents, brokerConfigMap, err := adaptor.AdaptPluginComponents(instance.Spec.WorkspaceId, instance.Namespace, pluginDevfileComponents)
+	pluginComponents, brokerConfigMap, err := adaptor.AdaptPluginComponents(instance.Spec.WorkspaceId, instance.Namespace, pluginDevfileComponents, commonEnv)
 	if err != nil {
 		reqLogger.Info("Failed to adapt plugin components")
 		return reconcile.Result{}, err
@@ -142,6 +150,26 @@ func (r *ReconcileComponent) Reconcile(request reconcile.Request) (reconcile.Res
 	return reconcile.Result{}, r.reconcileStatus(instance, components)
 }
 
+// getCommonEnv returns the environment variables that are added to all containers of the workspace that owns the
+// component, so that they can be referenced from the component's env and commands.
+func (r *ReconcileComponent) getCommonEnv(instance *workspacev1alpha1.Component) ([]corev1.EnvVar, error) {
+	owner := metav1.GetControllerOf(instance)
+	if owner == nil || owner.Kind != "Workspace" {
+		return nil, fmt.Errorf("component %s is not owned by a workspace", instance.Name)
+	}
+	workspace := &workspacev1alpha1.Workspace{}
+	namespacedName := types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      owner.Name,
+	}
+	err := r.client.Get(context.TODO(), namespacedName, workspace)
+	if err != nil {
+		return nil, err
+	}
+	creator := workspace.Labels[config.WorkspaceCreatorLabel]
+	return env.CommonEnvironmentVariables(workspace.Name, instance.Spec.WorkspaceId, instance.Namespace, creator), nil
+}
+
 func (r *ReconcileComponent) reconcileConfigMap(instance *workspacev1alpha1.Component, cm *corev1.ConfigMap, log logr.Logger) (ok bool, err error) {
 	err = controllerutil.SetControllerReference(instance, cm, r.scheme)
 	if err != nil {
@@ -0,0 +1,143 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"fmt"
+
+	workspacev1alpha1 "github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	sleepVolumeName = "sleep"
+	sleepVolumePath = "/image-puller"
+	// sleepBinary is the busybox binary copied from the sleep image; busybox runs the applet named after argv[0]
+	sleepBinary = sleepVolumePath + "/sleep"
+	// sleepSeconds is the longest duration supported by busybox sleep on all platforms
+	sleepSeconds = "2147483647"
+)
+
+// getSpecDaemonSet returns the DaemonSet that caches images on every node. Each image is run by a container that
+// sleeps, using a statically linked busybox binary copied into the pod by an init container, as images may not
+// provide a shell or a sleep binary. Images are pulled with the pull secrets from the controller config. The DaemonSet uses the default scheduling settings and security contexts from the
+// controller config, so that images are cached on the nodes where workspaces run.
+func getSpecDaemonSet(imagePuller *workspacev1alpha1.ImagePuller, images []string, scheme *runtime.Scheme) (*appsv1.DaemonSet, error) {
+	scheduling, err := config.ControllerCfg.GetSchedulingDefaults(imagePuller.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	podSecurityContext, err := config.ControllerCfg.GetPodSecurityContext()
+	if err != nil {
+		return nil, err
+	}
+	containerSecurityContext, err := config.ControllerCfg.GetContainerSecurityContext()
+	if err != nil {
+		return nil, err
+	}
+
+	sleepMount := corev1.VolumeMount{
+		Name:      sleepVolumeName,
+		MountPath: sleepVolumePath,
+	}
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("20Mi"),
+			corev1.ResourceCPU:    resource.MustParse("20m"),
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("10Mi"),
+			corev1.ResourceCPU:    resource.MustParse("5m"),
+		},
+	}
+	var containers []corev1.Container
+	for idx, image := range images {
+		containers = append(containers, corev1.Container{
+			Name:            fmt.Sprintf("image-%d", idx),
+			Image:           image,
+			Command:         []string{sleepBinary, sleepSeconds},
+			Resources:       resources,
+			VolumeMounts:    []corev1.VolumeMount{sleepMount},
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			SecurityContext: containerSecurityContext.DeepCopy(),
+		})
+	}
+
+	var imagePullSecrets []corev1.LocalObjectReference
+	for _, secretName := range config.ControllerCfg.GetImagePullerPullSecrets() {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: secretName})
+	}
+
+	labels := map[string]string{
+		config.ImagePullerLabel: "true",
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      imagePuller.Name,
+			Namespace: imagePuller.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name:            "copy-sleep",
+							Image:           config.ControllerCfg.GetImagePullerSleepImage(),
+							Command:         []string{"/bin/cp", "/bin/busybox", sleepBinary},
+							Resources:       resources,
+							VolumeMounts:    []corev1.VolumeMount{sleepMount},
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: containerSecurityContext.DeepCopy(),
+						},
+					},
+					Containers:       containers,
+					ImagePullSecrets: imagePullSecrets,
+					Volumes: []corev1.Volume{
+						{
+							Name: sleepVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					NodeSelector:      scheduling.NodeSelector,
+					Tolerations:       scheduling.Tolerations,
+					Affinity:          scheduling.Affinity,
+					PriorityClassName: scheduling.PriorityClassName,
+					SecurityContext:   podSecurityContext,
+				},
+			},
+		},
+	}
+	if profile := config.ControllerCfg.GetSeccompProfile(); profile != "" {
+		daemonSet.Spec.Template.Annotations = map[string]string{
+			corev1.SeccompPodAnnotationKey: profile,
+		}
+	}
+
+	err = controllerutil.SetControllerReference(imagePuller, daemonSet, scheme)
+	return daemonSet, err
+}
@@ -0,0 +1,343 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	internalcontroller "github.com/che-incubator/che-workspace-operator/internal/controller"
+	workspacev1alpha1 "github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	registry "github.com/che-incubator/che-workspace-operator/pkg/internal_registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var log = logf.Log.WithName("controller_imagepuller")
+
+var daemonSetDiffOpts = cmp.Options{
+	cmpopts.IgnoreFields(appsv1.DaemonSet{}, "TypeMeta", "ObjectMeta", "Status"),
+	cmpopts.IgnoreFields(appsv1.DaemonSetSpec{}, "RevisionHistoryLimit", "UpdateStrategy"),
+	cmpopts.IgnoreFields(corev1.PodSpec{}, "DNSPolicy", "SchedulerName", "DeprecatedServiceAccount", "RestartPolicy", "TerminationGracePeriodSeconds"),
+	cmpopts.IgnoreFields(corev1.Container{}, "TerminationMessagePath", "TerminationMessagePolicy"),
+}
+
+// Add creates a new ImagePuller Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileImagePuller{client: mgr.GetClient(), apiReader: mgr.GetAPIReader(), scheme: mgr.GetScheme()}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler. All watched objects enqueue the single
+// ImagePuller managed by the controller. DaemonSets, pods and deployments are only watched if they are labelled as
+// belonging to the image puller or to workspaces, to avoid caching all of them in the cluster.
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("imagepuller-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	imagePullerHandler := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(imagePullerMapper),
+	}
+
+	err = c.Watch(&source.Kind{Type: &workspacev1alpha1.ImagePuller{}}, imagePullerHandler)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the image puller's DaemonSet and pods, to report the images cached on each node
+	imagePullerSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.DaemonSetInformer, config.ImagePullerLabel+"=true")
+	if err != nil {
+		return err
+	}
+	podSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.PodInformer, config.ImagePullerLabel+"=true")
+	if err != nil {
+		return err
+	}
+	// Watch for changes to workspace deployments, to collect the images of running workspaces
+	deploymentSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.DeploymentInformer, config.WorkspaceIDLabel)
+	if err != nil {
+		return err
+	}
+	imagePullerSources = append(imagePullerSources, podSources...)
+	for _, src := range append(imagePullerSources, deploymentSources...) {
+		if err := c.Watch(src, imagePullerHandler); err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to the controller config, which enables the image puller and lists additional images
+	return c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, imagePullerHandler, metaPredicate(func(meta metav1.Object) bool {
+		return meta.GetNamespace() == config.ConfigMapReference.Namespace && meta.GetName() == config.ConfigMapReference.Name
+	}))
+}
+
+func imagePullerMapper(_ handler.MapObject) []reconcile.Request {
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      config.ImagePullerName,
+				Namespace: config.ConfigMapReference.Namespace,
+			},
+		},
+	}
+}
+
+func metaPredicate(matches func(meta metav1.Object) bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(evt event.CreateEvent) bool {
+			return matches(evt.Meta)
+		},
+		UpdateFunc: func(evt event.UpdateEvent) bool {
+			return matches(evt.MetaNew)
+		},
+		DeleteFunc: func(evt event.DeleteEvent) bool {
+			return matches(evt.Meta)
+		},
+		GenericFunc: func(evt event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// blank assignment to verify that ReconcileImagePuller implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileImagePuller{}
+
+// ReconcileImagePuller reconciles the ImagePuller in the controller's namespace
+type ReconcileImagePuller struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	// apiReader reads DaemonSets, pods and deployments from the API server, as they are not cached by the manager
+	apiReader client.Reader
+	scheme    *runtime.Scheme
+}
+
+// Reconcile ensures that the ImagePuller exists and that its DaemonSet caches the images of running workspaces, of the
+// internal registry, of the controller config and of the ImagePuller's spec if the image puller is enabled, and
+// removes the DaemonSet otherwise. The ImagePuller itself is kept, so that the images in its spec are preserved.
+func (r *ReconcileImagePuller) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	if request.Name != config.ImagePullerName || request.Namespace != config.ConfigMapReference.Namespace {
+		return reconcile.Result{}, nil
+	}
+
+	if config.ControllerCfg.GetImagePullerEnabled() != "true" {
+		return reconcile.Result{}, r.deleteDaemonSet(request.NamespacedName)
+	}
+	reqLogger.Info("Reconciling ImagePuller")
+
+	imagePuller := &workspacev1alpha1.ImagePuller{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, imagePuller)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Creating ImagePuller")
+		imagePuller = &workspacev1alpha1.ImagePuller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      request.Name,
+				Namespace: request.Namespace,
+			},
+		}
+		return reconcile.Result{}, r.client.Create(context.TODO(), imagePuller)
+	}
+
+	images, err := r.getImages(imagePuller)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	specDaemonSet, err := getSpecDaemonSet(imagePuller, images, r.scheme)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	clusterDaemonSet := &appsv1.DaemonSet{}
+	err = r.apiReader.Get(context.TODO(), request.NamespacedName, clusterDaemonSet)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Creating image puller DaemonSet")
+		return reconcile.Result{}, r.client.Create(context.TODO(), specDaemonSet)
+	}
+	if !cmp.Equal(specDaemonSet, clusterDaemonSet, daemonSetDiffOpts) {
+		reqLogger.Info("Updating image puller DaemonSet")
+		clusterDaemonSet.Spec = specDaemonSet.Spec
+		err := r.client.Update(context.TODO(), clusterDaemonSet)
+		if err != nil && !errors.IsConflict(err) {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	pods := &corev1.PodList{}
+	err = r.apiReader.List(context.TODO(), pods, client.InNamespace(request.Namespace), client.MatchingLabels{config.ImagePullerLabel: "true"})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status := getImagePullerStatus(clusterDaemonSet, pods.Items, images)
+	if equality.Semantic.DeepEqual(status, imagePuller.Status) {
+		return reconcile.Result{}, nil
+	}
+	imagePuller.Status = status
+	return reconcile.Result{}, r.client.Status().Update(context.TODO(), imagePuller)
+}
+
+// getImages returns the sorted images to be cached: the images of the ImagePuller's spec and of the controller config,
+// the images of the plugins in the internal registry, and the allowed images of the workspaces that are running. At
+// most the number of images configured in the controller config is returned, in that order of precedence.
+func (r *ReconcileImagePuller) getImages(imagePuller *workspacev1alpha1.ImagePuller) ([]string, error) {
+	maxImages, err := config.ControllerCfg.GetImagePullerMaxImages()
+	if err != nil {
+		return nil, err
+	}
+	registryImages, err := registry.GetInternalRegistryImages()
+	if err != nil {
+		return nil, err
+	}
+	registryImageSet := map[string]bool{}
+	for _, image := range registryImages {
+		registryImageSet[image] = true
+	}
+
+	var candidates []string
+	candidates = append(candidates, imagePuller.Spec.Images...)
+	candidates = append(candidates, config.ControllerCfg.GetImagePullerImages()...)
+	candidates = append(candidates, registryImages...)
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.apiReader.List(context.TODO(), deployments, client.HasLabels{config.WorkspaceIDLabel}); err != nil {
+		return nil, err
+	}
+	allowedImages := config.ControllerCfg.GetImagePullerAllowedWorkspaceImages()
+	var workspaceImages []string
+	for _, deployment := range deployments.Items {
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+			// Workspace is stopped
+			continue
+		}
+		podSpec := deployment.Spec.Template.Spec
+		for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+			if registryImageSet[container.Image] || isImageAllowed(allowedImages, container.Image) {
+				workspaceImages = append(workspaceImages, container.Image)
+			}
+		}
+	}
+	sort.Strings(workspaceImages)
+	candidates = append(candidates, workspaceImages...)
+
+	images := map[string]bool{}
+	var sortedImages []string
+	for _, image := range candidates {
+		if image == "" || images[image] {
+			continue
+		}
+		if len(sortedImages) == maxImages {
+			log.Info("Maximum number of cached images reached; not caching remaining images", "maxImages", maxImages, "image", image)
+			break
+		}
+		images[image] = true
+		sortedImages = append(sortedImages, image)
+	}
+	sort.Strings(sortedImages)
+	return sortedImages, nil
+}
+
+// isImageAllowed returns whether an image is in allowList, where entries ending with '*' match images by prefix
+func isImageAllowed(allowList []string, image string) bool {
+	for _, allowed := range allowList {
+		if strings.HasSuffix(allowed, "*") && strings.HasPrefix(image, strings.TrimSuffix(allowed, "*")) {
+			return true
+		}
+		if allowed == image {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileImagePuller) deleteDaemonSet(name types.NamespacedName) error {
+	daemonSet := &appsv1.DaemonSet{}
+	err := r.apiReader.Get(context.TODO(), name, daemonSet)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	log.Info("Image puller is disabled; deleting DaemonSet")
+	err = r.client.Delete(context.TODO(), daemonSet)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// getImagePullerStatus returns the number of nodes each image is cached on, based on the container statuses of the
+// image puller's pods. Pods of previous revisions of the DaemonSet are counted for the images they still contain.
+func getImagePullerStatus(daemonSet *appsv1.DaemonSet, pods []corev1.Pod, images []string) workspacev1alpha1.ImagePullerStatus {
+	nodes := map[string]int32{}
+	messages := map[string]string{}
+	for _, pod := range pods {
+		containerImages := map[string]string{}
+		for _, container := range pod.Spec.Containers {
+			containerImages[container.Name] = container.Image
+		}
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			image := containerImages[containerStatus.Name]
+			if containerStatus.ImageID != "" {
+				nodes[image]++
+			} else if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Message != "" && messages[image] == "" {
+				messages[image] = fmt.Sprintf("node %s: %s", pod.Spec.NodeName, waiting.Message)
+			}
+		}
+	}
+
+	status := workspacev1alpha1.ImagePullerStatus{
+		DesiredNodes: daemonSet.Status.DesiredNumberScheduled,
+		ReadyNodes:   daemonSet.Status.NumberReady,
+	}
+	for _, image := range images {
+		status.Images = append(status.Images, workspacev1alpha1.ImagePullerImageStatus{
+			Image:   image,
+			Nodes:   nodes[image],
+			Message: messages[image],
+		})
+	}
+	return status
+}
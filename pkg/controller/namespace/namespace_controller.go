@@ -0,0 +1,298 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package namespace
+
+import (
+	"context"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	resourceQuotaName = "che-workspace-quota"
+	limitRangeName    = "che-workspace-limits"
+	networkPolicyName = "che-workspace-network-policy"
+	roleBindingName   = "che-workspace-user"
+)
+
+var log = logf.Log.WithName("controller_namespace")
+
+// Add creates a new Namespace Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileNamespace{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("namespace-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to namespaces provisioned for users
+	err = c.Watch(&source.Kind{Type: &corev1.Namespace{}}, &handler.EnqueueRequestForObject{}, metaPredicate(isUserNamespace))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the objects provisioned in user namespaces, to revert changes by users
+	for _, obj := range []runtime.Object{&corev1.ResourceQuota{}, &corev1.LimitRange{}, &networkingv1.NetworkPolicy{}, &rbacv1.RoleBinding{}} {
+		err = c.Watch(&source.Kind{Type: obj}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &corev1.Namespace{},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to the controller config, which defines the objects provisioned in user namespaces
+	cl := mgr.GetClient()
+	return c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(_ handler.MapObject) []reconcile.Request {
+			namespaces := &corev1.NamespaceList{}
+			if err := cl.List(context.TODO(), namespaces, client.MatchingLabels{config.UserNamespaceLabel: "true"}); err != nil {
+				log.Error(err, "Failed to list user namespaces")
+				return nil
+			}
+			var requests []reconcile.Request
+			for _, namespace := range namespaces.Items {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: namespace.Name}})
+			}
+			return requests
+		}),
+	}, metaPredicate(func(meta metav1.Object) bool {
+		return meta.GetNamespace() == config.ConfigMapReference.Namespace && meta.GetName() == config.ConfigMapReference.Name
+	}))
+}
+
+func isUserNamespace(meta metav1.Object) bool {
+	return meta.GetLabels()[config.UserNamespaceLabel] == "true"
+}
+
+func metaPredicate(matches func(meta metav1.Object) bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(evt event.CreateEvent) bool {
+			return matches(evt.Meta)
+		},
+		UpdateFunc: func(evt event.UpdateEvent) bool {
+			return matches(evt.MetaNew)
+		},
+		DeleteFunc: func(evt event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(evt event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// blank assignment to verify that ReconcileNamespace implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileNamespace{}
+
+// ReconcileNamespace reconciles the namespaces provisioned for users
+type ReconcileNamespace struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// provisionedObject is an object provisioned in user namespaces
+type provisionedObject interface {
+	runtime.Object
+	metav1.Object
+}
+
+// Reconcile provisions the ResourceQuota, LimitRange, NetworkPolicy and the RoleBinding of the user in namespaces
+// provisioned for users, as defined in the controller config. Namespaces are provisioned by the webhook server when
+// users create workspaces; they are kept if namespace provisioning is disabled later.
+func (r *ReconcileNamespace) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Name", request.Name)
+	if config.ControllerCfg.GetNamespaceProvisioningEnabled() != "true" {
+		return reconcile.Result{}, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: request.Name}, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !isUserNamespace(namespace) || namespace.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+	username := namespace.Annotations[config.UserNamespaceUsernameAnnotation]
+	if username == "" {
+		reqLogger.Info("Namespace is not annotated with the name of its user; skipping", "annotation", config.UserNamespaceUsernameAnnotation)
+		return reconcile.Result{}, nil
+	}
+	reqLogger.Info("Reconciling user namespace")
+
+	quotaSpec, err := config.ControllerCfg.GetNamespaceResourceQuota()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: resourceQuotaName, Namespace: namespace.Name}}
+	if quotaSpec != nil {
+		quota.Spec = *quotaSpec
+	}
+	clusterQuota := &corev1.ResourceQuota{}
+	err = r.syncObject(namespace, quota, clusterQuota, quotaSpec != nil, func() bool {
+		if equality.Semantic.DeepEqual(clusterQuota.Spec, quota.Spec) {
+			return false
+		}
+		clusterQuota.Spec = quota.Spec
+		return true
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	limitRangeSpec, err := config.ControllerCfg.GetNamespaceLimitRange()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: limitRangeName, Namespace: namespace.Name}}
+	if limitRangeSpec != nil {
+		limitRange.Spec = *limitRangeSpec
+	}
+	clusterLimitRange := &corev1.LimitRange{}
+	err = r.syncObject(namespace, limitRange, clusterLimitRange, limitRangeSpec != nil, func() bool {
+		if equality.Semantic.DeepEqual(clusterLimitRange.Spec, limitRange.Spec) {
+			return false
+		}
+		clusterLimitRange.Spec = limitRange.Spec
+		return true
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	networkPolicySpec, err := config.ControllerCfg.GetNamespaceNetworkPolicy()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	networkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: networkPolicyName, Namespace: namespace.Name}}
+	if networkPolicySpec != nil {
+		networkPolicy.Spec = *networkPolicySpec
+	}
+	clusterNetworkPolicy := &networkingv1.NetworkPolicy{}
+	err = r.syncObject(namespace, networkPolicy, clusterNetworkPolicy, networkPolicySpec != nil, func() bool {
+		if equality.Semantic.DeepEqual(clusterNetworkPolicy.Spec, networkPolicy.Spec) {
+			return false
+		}
+		clusterNetworkPolicy.Spec = networkPolicy.Spec
+		return true
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     config.ControllerCfg.GetNamespaceUserClusterRole(),
+	}
+	subjects := []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: username}}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: roleBindingName, Namespace: namespace.Name},
+		RoleRef:    roleRef,
+		Subjects:   subjects,
+	}
+	clusterRoleBinding := &rbacv1.RoleBinding{}
+	roleRefChanged := false
+	err = r.syncObject(namespace, roleBinding, clusterRoleBinding, true, func() bool {
+		if clusterRoleBinding.RoleRef != roleRef {
+			// The role of a RoleBinding is immutable; the RoleBinding is recreated once it is deleted
+			roleRefChanged = true
+			return false
+		}
+		if equality.Semantic.DeepEqual(clusterRoleBinding.Subjects, subjects) {
+			return false
+		}
+		clusterRoleBinding.Subjects = subjects
+		return true
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if roleRefChanged {
+		reqLogger.Info("Deleting user RoleBinding to change its role")
+		err := r.client.Delete(context.TODO(), clusterRoleBinding)
+		if err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// syncObject creates spec in the namespace if it does not exist. Otherwise, the object is read into cluster and is
+// updated if updateSpec reports that it was changed to match spec. If the object is not enabled in the controller config, it is deleted.
+func (r *ReconcileNamespace) syncObject(namespace *corev1.Namespace, spec, cluster provisionedObject, enabled bool, updateSpec func() bool) error {
+	reqLogger := log.WithValues("Request.Name", namespace.Name, "Object.Name", spec.GetName())
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: spec.GetName(), Namespace: spec.GetNamespace()}, cluster)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if !enabled {
+			return nil
+		}
+		if err := controllerutil.SetControllerReference(namespace, spec, r.scheme); err != nil {
+			return err
+		}
+		reqLogger.Info("Creating object in user namespace")
+		return r.client.Create(context.TODO(), spec)
+	}
+	if !enabled {
+		reqLogger.Info("Deleting object from user namespace")
+		err := r.client.Delete(context.TODO(), cluster)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	if updateSpec() {
+		reqLogger.Info("Updating object in user namespace")
+		return r.client.Update(context.TODO(), cluster)
+	}
+	return nil
+}
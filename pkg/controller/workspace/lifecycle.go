@@ -0,0 +1,128 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspace
+
+import (
+	"context"
+	"sort"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reasons of the events recorded by the kubelet when a container lifecycle hook fails
+const (
+	failedPostStartHookReason = "FailedPostStartHook"
+	failedPreStopHookReason   = "FailedPreStopHook"
+)
+
+// getWorkspacePods returns the pods of a workspace, including terminating ones
+func (r *ReconcileWorkspace) getWorkspacePods(workspace *v1alpha1.Workspace) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	// Pods are read from the API server directly, as only the pods of workspaces are cached to watch them
+	err := r.apiReader.List(context.TODO(), pods, client.InNamespace(workspace.Namespace),
+		client.MatchingLabels{config.WorkspaceIDLabel: workspace.Status.WorkspaceId})
+	if err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// getLifecycleHookFailure returns the message of the latest event with the given reason recorded for any of pods, or
+// an empty string if there is none. The message contains the output of the failed hook.
+func (r *ReconcileWorkspace) getLifecycleHookFailure(pods []corev1.Pod, reason string) (string, error) {
+	var failures []corev1.Event
+	for _, pod := range pods {
+		events := &corev1.EventList{}
+		// Events are read from the API server directly, as caching all events in the cluster would be expensive
+		err := r.apiReader.List(context.TODO(), events, client.InNamespace(pod.Namespace),
+			client.MatchingFieldsSelector{Selector: fields.SelectorFromSet(fields.Set{
+				"involvedObject.uid": string(pod.UID),
+				"reason":             reason,
+			})})
+		if err != nil {
+			return "", err
+		}
+		failures = append(failures, events.Items...)
+	}
+	if len(failures) == 0 {
+		return "", nil
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].LastTimestamp.Before(&failures[j].LastTimestamp)
+	})
+	return failures[len(failures)-1].Message, nil
+}
+
+// getPostStartFailure returns the output of the postStart commands of a starting workspace if they failed, or an empty
+// string otherwise
+func (r *ReconcileWorkspace) getPostStartFailure(workspace *v1alpha1.Workspace) (string, error) {
+	pods, err := r.getWorkspacePods(workspace)
+	if err != nil {
+		return "", err
+	}
+	// Pods of a previous revision of the workspace deployment may still be terminating
+	var currentPods []corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			currentPods = append(currentPods, pod)
+		}
+	}
+	return r.getLifecycleHookFailure(currentPods, failedPostStartHookReason)
+}
+
+// checkPreStopCommands sets the PreStopCommandsSucceeded condition of a stopping workspace to false if its preStop
+// commands failed in any of its pods. As pods are deleted once they are stopped, a failure that was already reported
+// is kept until the workspace is started again.
+func (r *ReconcileWorkspace) checkPreStopCommands(workspace *v1alpha1.Workspace, pods []corev1.Pod, status *currentStatus) error {
+	for _, condition := range workspace.Status.Conditions {
+		if condition.Type == v1alpha1.WorkspacePreStopCommandsSucceeded && condition.Status == corev1.ConditionFalse {
+			status.FailedConditions = map[v1alpha1.WorkspaceConditionType]string{
+				v1alpha1.WorkspacePreStopCommandsSucceeded: condition.Message,
+			}
+			return nil
+		}
+	}
+	failure, err := r.getLifecycleHookFailure(pods, failedPreStopHookReason)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		status.FailedConditions = map[v1alpha1.WorkspaceConditionType]string{
+			v1alpha1.WorkspacePreStopCommandsSucceeded: failure,
+		}
+	}
+	return nil
+}
+
+// workspacePodMapper enqueues the workspace that a pod belongs to
+func workspacePodMapper(obj handler.MapObject) []reconcile.Request {
+	workspaceName, ok := obj.Meta.GetLabels()[config.WorkspaceNameLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      workspaceName,
+				Namespace: obj.Meta.GetNamespace(),
+			},
+		},
+	}
+}
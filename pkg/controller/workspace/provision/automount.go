@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	autoMountAsFile = "file"
+	autoMountAsEnv  = "env"
+)
+
+// getAutoMountPodAdditions collects the Secrets and ConfigMaps in namespace that are labelled with
+// config.WorkspaceMountLabel and returns the PodAdditions required to provide them to all workspace containers. The
+// returned PodAdditions also carry a pod annotation that changes whenever any of the objects change, in order to
+// restart workspaces when that happens. Objects with an invalid mount-as annotation are skipped and logged rather than
+// failing, as they may be unrelated to the workspaces and would otherwise prevent all workspaces in the namespace from
+// starting.
+func getAutoMountPodAdditions(namespace string, client runtimeClient.Client) (*v1alpha1.PodAdditions, error) {
+	listOptions := []runtimeClient.ListOption{
+		runtimeClient.InNamespace(namespace),
+		runtimeClient.MatchingLabels{config.WorkspaceMountLabel: "true"},
+	}
+	secrets := &corev1.SecretList{}
+	if err := client.List(context.TODO(), secrets, listOptions...); err != nil {
+		return nil, err
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := client.List(context.TODO(), configMaps, listOptions...); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		return secrets.Items[i].Name < secrets.Items[j].Name
+	})
+	sort.Slice(configMaps.Items, func(i, j int) bool {
+		return configMaps.Items[i].Name < configMaps.Items[j].Name
+	})
+
+	podAdditions := &v1alpha1.PodAdditions{}
+	var versions []string
+	for _, secret := range secrets.Items {
+		mountAs := getAutoMountAs(secret.Annotations)
+		switch mountAs {
+		case autoMountAsFile:
+			volumeName := common.ObjectMountVolumeName("secret", "automount", secret.Name)
+			podAdditions.Volumes = append(podAdditions.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: secret.Name,
+					},
+				},
+			})
+			podAdditions.VolumeMounts = append(podAdditions.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: getAutoMountPath(secret.Annotations, path.Join("/etc/secret", secret.Name)),
+				ReadOnly:  true,
+			})
+		case autoMountAsEnv:
+			podAdditions.EnvFrom = append(podAdditions.EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				},
+			})
+		default:
+			log.Info("Skipping automounted secret with invalid annotation", "namespace", namespace, "secret", secret.Name,
+				"annotation", config.WorkspaceMountAsAnnotation, "value", mountAs)
+			continue
+		}
+		versions = append(versions, fmt.Sprintf("secret/%s:%s", secret.Name, secret.ResourceVersion))
+	}
+	for _, configMap := range configMaps.Items {
+		mountAs := getAutoMountAs(configMap.Annotations)
+		switch mountAs {
+		case autoMountAsFile:
+			volumeName := common.ObjectMountVolumeName("configmap", "automount", configMap.Name)
+			podAdditions.Volumes = append(podAdditions.Volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+					},
+				},
+			})
+			podAdditions.VolumeMounts = append(podAdditions.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: getAutoMountPath(configMap.Annotations, path.Join("/etc/config", configMap.Name)),
+				ReadOnly:  true,
+			})
+		case autoMountAsEnv:
+			podAdditions.EnvFrom = append(podAdditions.EnvFrom, corev1.EnvFromSource{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+				},
+			})
+		default:
+			log.Info("Skipping automounted configmap with invalid annotation", "namespace", namespace, "configmap", configMap.Name,
+				"annotation", config.WorkspaceMountAsAnnotation, "value", mountAs)
+			continue
+		}
+		versions = append(versions, fmt.Sprintf("configmap/%s:%s", configMap.Name, configMap.ResourceVersion))
+	}
+
+	if len(versions) > 0 {
+		sort.Strings(versions)
+		hash := sha256.New()
+		for _, version := range versions {
+			hash.Write([]byte(version))
+		}
+		podAdditions.Annotations = map[string]string{
+			config.WorkspaceAutoMountHashAnnotation: fmt.Sprintf("%x", hash.Sum(nil))[:16],
+		}
+	}
+	return podAdditions, nil
+}
+
+func getAutoMountAs(annotations map[string]string) string {
+	if mountAs, ok := annotations[config.WorkspaceMountAsAnnotation]; ok && mountAs != "" {
+		return mountAs
+	}
+	return autoMountAsFile
+}
+
+func getAutoMountPath(annotations map[string]string, defaultPath string) string {
+	if mountPath, ok := annotations[config.WorkspaceMountPathAnnotation]; ok && mountPath != "" {
+		return mountPath
+	}
+	return defaultPath
+}
@@ -44,8 +44,8 @@ var componentDiffOpts = cmp.Options{
 }
 
 func SyncComponentsToCluster(
-	workspace *v1alpha1.Workspace, clusterAPI ClusterAPI) ComponentProvisioningStatus {
-	specComponents, err := getSpecComponents(workspace, clusterAPI.Scheme)
+	workspace *v1alpha1.Workspace, devfile *v1alpha1.DevfileSpec, clusterAPI ClusterAPI) ComponentProvisioningStatus {
+	specComponents, err := getSpecComponents(workspace, devfile, clusterAPI.Scheme)
 	if err != nil {
 		return ComponentProvisioningStatus{
 			ProvisioningStatus: ProvisioningStatus{Err: err},
@@ -125,8 +125,8 @@ func checkComponentsReadiness(components []v1alpha1.Component) ComponentProvisio
 	}
 }
 
-func getSpecComponents(workspace *v1alpha1.Workspace, scheme *runtime.Scheme) ([]v1alpha1.Component, error) {
-	dockerComponents, pluginComponents, err := adaptor.SortComponentsByType(workspace.Spec.Devfile.Components)
+func getSpecComponents(workspace *v1alpha1.Workspace, devfile *v1alpha1.DevfileSpec, scheme *runtime.Scheme) ([]v1alpha1.Component, error) {
+	dockerComponents, pluginComponents, err := adaptor.SortComponentsByType(devfile.Components)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +155,8 @@ func getSpecComponents(workspace *v1alpha1.Workspace, scheme *runtime.Scheme) ([
 			Spec: v1alpha1.WorkspaceComponentSpec{
 				WorkspaceId: workspace.Status.WorkspaceId,
 				Components:  dockerComponents,
-				Commands:    workspace.Spec.Devfile.Commands,
+				Commands:    devfile.Commands,
+				Events:      devfile.Events,
 			},
 		}
 		err = controllerutil.SetControllerReference(workspace, &dockerResolver, scheme)
@@ -176,7 +177,7 @@ func getSpecComponents(workspace *v1alpha1.Workspace, scheme *runtime.Scheme) ([
 			Spec: v1alpha1.WorkspaceComponentSpec{
 				WorkspaceId: workspace.Status.WorkspaceId,
 				Components:  pluginComponents,
-				Commands:    workspace.Spec.Devfile.Commands,
+				Commands:    devfile.Commands,
 			},
 		}
 		err = controllerutil.SetControllerReference(workspace, &pluginResolver, scheme)
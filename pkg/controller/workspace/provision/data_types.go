@@ -23,11 +23,16 @@ type ProvisioningStatus struct {
 	Continue    bool
 	Requeue     bool
 	FailStartup bool
-	Err         error
+	// Message describes why the workspace cannot be started, if FailStartup is true
+	Message string
+	Err     error
 }
 
 type ClusterAPI struct {
 	Client client.Client
-	Scheme *runtime.Scheme
-	Logger logr.Logger
+	// NonCachingClient reads objects from the API server directly. It is used for Secrets, which are not cached by the
+	// controller, and for the ConfigMaps provisioned along with them.
+	NonCachingClient client.Client
+	Scheme           *runtime.Scheme
+	Logger           logr.Logger
 }
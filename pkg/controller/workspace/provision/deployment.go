@@ -15,6 +15,7 @@ package provision
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/che-incubator/che-workspace-operator/pkg/common"
@@ -34,8 +35,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// Termination grace period for workspaces that have preStop commands, which are killed once it elapses
+const preStopTerminationGracePeriod = int64(30)
+
 type DeploymentProvisioningStatus struct {
 	ProvisioningStatus
+	// Whether the workspace is running with an outdated deployment, as its restart waits for confirmation
+	RestartPending bool
+	// Whether the deployment of a running workspace was updated, which restarts the workspace
+	Restarting bool
 }
 
 var deploymentDiffOpts = cmp.Options{
@@ -60,6 +68,14 @@ func SyncDeploymentToCluster(
 
 	// [design] we have to pass components and routing pod additions separately because we need mountsources from each
 	// component.
+	autoMountPodAdditions, err := getAutoMountPodAdditions(workspace.Namespace, clusterAPI.NonCachingClient)
+	if err != nil {
+		return DeploymentProvisioningStatus{
+			ProvisioningStatus: ProvisioningStatus{Err: err},
+		}
+	}
+	podAdditions = append(podAdditions, *autoMountPodAdditions)
+
 	specDeployment, err := getSpecDeployment(workspace, podAdditions, components, saName, clusterAPI.Scheme)
 	if err != nil {
 		return DeploymentProvisioningStatus{
@@ -86,17 +102,39 @@ func SyncDeploymentToCluster(
 	}
 
 	if !cmp.Equal(specDeployment, clusterDeployment, deploymentDiffOpts) {
+		// The deployment uses the Recreate strategy, as the workspace PVC may not be mounted by several pods, so
+		// updating the deployment of a running workspace restarts it.
+		running := checkDeploymentStatus(clusterDeployment)
+		if running && workspace.Spec.UpdatePolicy == v1alpha1.WorkspaceUpdateOnConfirmation &&
+			workspace.Annotations[config.WorkspaceConfirmRestartAnnotation] != "true" {
+			clusterAPI.Logger.Info("Workspace restart is pending confirmation")
+			return DeploymentProvisioningStatus{
+				ProvisioningStatus: ProvisioningStatus{Continue: true},
+				RestartPending:     true,
+			}
+		}
 		clusterAPI.Logger.Info("Updating deployment...")
 		clusterDeployment.Spec = specDeployment.Spec
+		setAppliedGeneration(clusterDeployment, workspace)
 		err := clusterAPI.Client.Update(context.TODO(), clusterDeployment)
 		if err != nil {
 			if errors.IsConflict(err) {
 				return DeploymentProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Requeue: true}}
 			}
-			return DeploymentProvisioningStatus{ProvisioningStatus{Err: err}}
+			return DeploymentProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
 		}
 		return DeploymentProvisioningStatus{
 			ProvisioningStatus: ProvisioningStatus{Requeue: true},
+			Restarting:         running,
+		}
+	}
+
+	if clusterDeployment.Annotations[config.WorkspaceAppliedGenerationAnnotation] != specDeployment.Annotations[config.WorkspaceAppliedGenerationAnnotation] {
+		// Changes of the workspace spec may not change the deployment, e.g. when the workspace is shared
+		patch := runtimeClient.MergeFrom(clusterDeployment.DeepCopy())
+		setAppliedGeneration(clusterDeployment, workspace)
+		if err := clusterAPI.Client.Patch(context.TODO(), clusterDeployment, patch); err != nil {
+			return DeploymentProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
 		}
 	}
 
@@ -116,6 +154,31 @@ func checkDeploymentStatus(deployment *appsv1.Deployment) (ready bool) {
 	return deployment.Status.ReadyReplicas > 0
 }
 
+// setAppliedGeneration records on a workspace deployment that the current spec of the workspace is applied to it
+func setAppliedGeneration(deployment *appsv1.Deployment, workspace *v1alpha1.Workspace) {
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[config.WorkspaceAppliedGenerationAnnotation] = strconv.FormatInt(workspace.Generation, 10)
+}
+
+// IsRestartPending returns whether a running workspace with the OnConfirmation update policy has changes that wait for
+// the restart to be confirmed. Deployments that do not record the applied generation are checked when they are synced
+// instead.
+func IsRestartPending(workspace *v1alpha1.Workspace, client runtimeClient.Client) (bool, error) {
+	if workspace.Spec.UpdatePolicy != v1alpha1.WorkspaceUpdateOnConfirmation ||
+		workspace.Annotations[config.WorkspaceConfirmRestartAnnotation] == "true" ||
+		workspace.Status.Phase != v1alpha1.WorkspaceStatusRunning {
+		return false, nil
+	}
+	deployment, err := getClusterDeployment(common.DeploymentName(workspace.Status.WorkspaceId), workspace.Namespace, client)
+	if err != nil || deployment == nil || !checkDeploymentStatus(deployment) {
+		return false, err
+	}
+	appliedGeneration, ok := deployment.Annotations[config.WorkspaceAppliedGenerationAnnotation]
+	return ok && appliedGeneration != strconv.FormatInt(workspace.Generation, 10), nil
+}
+
 func getSpecDeployment(
 	workspace *v1alpha1.Workspace,
 	podAdditionsList []v1alpha1.PodAdditions,
@@ -125,26 +188,31 @@ func getSpecDeployment(
 	replicas := int32(1)
 	terminationGracePeriod := int64(1)
 
-	var user *int64
-	if !config.ControllerCfg.IsOpenShift() {
-		uID := int64(1234)
-		user = &uID
-	}
-
 	podAdditions, err := mergePodAdditions(podAdditionsList)
 	if err != nil {
 		return nil, err
 	}
+	for _, container := range podAdditions.Containers {
+		if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+			terminationGracePeriod = preStopTerminationGracePeriod
+			break
+		}
+	}
+	if workspace.Spec.TerminationGracePeriodSeconds != nil {
+		terminationGracePeriod = *workspace.Spec.TerminationGracePeriodSeconds
+	}
 
 	creator := workspace.Labels[config.WorkspaceCreatorLabel]
 	commonEnv := env.CommonEnvironmentVariables(workspace.Name, workspace.Status.WorkspaceId, workspace.Namespace, creator)
 	for idx := range podAdditions.Containers {
 		podAdditions.Containers[idx].Env = append(podAdditions.Containers[idx].Env, commonEnv...)
 		podAdditions.Containers[idx].VolumeMounts = append(podAdditions.Containers[idx].VolumeMounts, podAdditions.VolumeMounts...)
+		podAdditions.Containers[idx].EnvFrom = append(podAdditions.Containers[idx].EnvFrom, podAdditions.EnvFrom...)
 	}
 	for idx := range podAdditions.InitContainers {
 		podAdditions.InitContainers[idx].Env = append(podAdditions.InitContainers[idx].Env, commonEnv...)
 		podAdditions.InitContainers[idx].VolumeMounts = append(podAdditions.InitContainers[idx].VolumeMounts, podAdditions.VolumeMounts...)
+		podAdditions.InitContainers[idx].EnvFrom = append(podAdditions.InitContainers[idx].EnvFrom, podAdditions.EnvFrom...)
 	}
 
 	deployment := &appsv1.Deployment{
@@ -182,22 +250,34 @@ func getSpecDeployment(
 					Volumes:                       podAdditions.Volumes,
 					RestartPolicy:                 "Always",
 					TerminationGracePeriodSeconds: &terminationGracePeriod,
-					SecurityContext: &corev1.PodSecurityContext{
-						RunAsUser: user,
-						FSGroup:   user,
-					},
-					ServiceAccountName:           saName,
-					AutomountServiceAccountToken: nil,
+					ServiceAccountName:            saName,
+					AutomountServiceAccountToken:  nil,
 				},
 			},
 		},
 	}
 
+	setAppliedGeneration(deployment, workspace)
+
+	if err := applyScheduling(workspace, &deployment.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+
 	if IsPVCRequired(components) {
 		deployment.Spec.Template.Spec.InitContainers = append(deployment.Spec.Template.Spec.InitContainers, precreateSubpathsInitContainer(workspace.Status.WorkspaceId))
 		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, getPersistentVolumeClaim())
 	}
 
+	if len(podAdditions.Annotations) > 0 {
+		deployment.Spec.Template.Annotations = podAdditions.Annotations
+	}
+	if err := applySecurityContext(workspace, &deployment.Spec.Template); err != nil {
+		return nil, err
+	}
+	for labelKey, labelVal := range podAdditions.Labels {
+		deployment.Spec.Template.Labels[labelKey] = labelVal
+	}
+
 	workspaceCreator, present := workspace.Labels[config.WorkspaceCreatorLabel]
 	if present {
 		deployment.Labels[config.WorkspaceCreatorLabel] = workspaceCreator
@@ -229,7 +309,10 @@ func getClusterDeployment(name string, namespace string, client runtimeClient.Cl
 }
 
 func mergePodAdditions(toMerge []v1alpha1.PodAdditions) (*v1alpha1.PodAdditions, error) {
-	podAdditions := &v1alpha1.PodAdditions{}
+	podAdditions := &v1alpha1.PodAdditions{
+		Annotations: map[string]string{},
+		Labels:      map[string]string{},
+	}
 
 	// "Set"s to store k8s object names and detect duplicates
 	containerNames := map[string]bool{}
@@ -276,6 +359,8 @@ func mergePodAdditions(toMerge []v1alpha1.PodAdditions) (*v1alpha1.PodAdditions,
 			podAdditions.VolumeMounts = append(podAdditions.VolumeMounts, volumeMount)
 		}
 
+		podAdditions.EnvFrom = append(podAdditions.EnvFrom, additions.EnvFrom...)
+
 		for _, pullSecret := range additions.PullSecrets {
 			if pullSecretNames[pullSecret.Name] {
 				continue
@@ -0,0 +1,380 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// gitConfigMountPath is the system-wide git config, so that it applies regardless of the user's home directory
+	gitConfigMountPath      = "/etc/gitconfig"
+	gitCredentialsMountPath = "/etc/git-credentials"
+
+	gitConfigKey        = "gitconfig"
+	gitCredentialsKey   = "credentials"
+	sshConfigKey        = "ssh_config"
+	sshKnownHostsKey    = "known_hosts"
+	sshPrivateKeyPrefix = "id_"
+
+	gitConfigVolumeName      = "git-config"
+	gitCredentialsVolumeName = "git-credentials"
+)
+
+type GitConfigProvisioningStatus struct {
+	ProvisioningStatus
+	PodAdditions *v1alpha1.PodAdditions
+}
+
+// SyncGitConfig provisions the git configuration of a workspace: the user's identity taken from the workspace's
+// annotations, the git credentials from Secrets labelled with config.WorkspaceGitCredentialLabel and the SSH keys
+// from Secrets labelled with config.WorkspaceGitSSHKeyLabel. The returned PodAdditions mount the configuration into all
+// workspace containers, including init containers, so that it is also available when cloning projects.
+func SyncGitConfig(workspace *v1alpha1.Workspace, clusterAPI ClusterAPI) GitConfigProvisioningStatus {
+	credentials, err := getGitCredentials(workspace.Namespace, clusterAPI.NonCachingClient)
+	if err != nil {
+		return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
+	}
+	sshKeys, knownHosts, err := getGitSSHKeys(workspace.Namespace, clusterAPI.NonCachingClient)
+	if err != nil {
+		return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
+	}
+	if len(sshKeys) > 0 && knownHosts == "" {
+		return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{
+			FailStartup: true,
+			Message: fmt.Sprintf("git SSH keys are configured, but no Secret labelled with %s contains '%s' entries for "+
+				"the git servers' host keys", config.WorkspaceGitSSHKeyLabel, sshKnownHostsKey),
+		}}
+	}
+	userName := workspace.Annotations[config.WorkspaceGitUserNameAnnotation]
+	userEmail := workspace.Annotations[config.WorkspaceGitUserEmailAnnotation]
+	for _, annotation := range []string{config.WorkspaceGitUserNameAnnotation, config.WorkspaceGitUserEmailAnnotation} {
+		if strings.IndexFunc(workspace.Annotations[annotation], unicode.IsControl) >= 0 {
+			return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{
+				FailStartup: true,
+				Message:     fmt.Sprintf("annotation %s must not contain newlines or other control characters", annotation),
+			}}
+		}
+	}
+
+	if userName == "" && userEmail == "" && credentials == "" && len(sshKeys) == 0 {
+		// Remove the configuration provisioned before, so that removed credentials do not remain in the namespace
+		err := deleteGitConfig(workspace, clusterAPI, true)
+		if err != nil {
+			return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
+		}
+		return GitConfigProvisioningStatus{
+			ProvisioningStatus: ProvisioningStatus{Continue: true},
+			PodAdditions:       &v1alpha1.PodAdditions{},
+		}
+	}
+
+	secretData := map[string][]byte{}
+	if credentials != "" {
+		secretData[gitCredentialsKey] = []byte(credentials)
+	}
+	if len(sshKeys) > 0 {
+		for keyName, key := range sshKeys {
+			secretData[keyName] = key
+		}
+		secretData[sshConfigKey] = []byte(getSSHConfig(sshKeys))
+		secretData[sshKnownHostsKey] = []byte(knownHosts)
+	}
+	gitConfig := getGitConfig(userName, userEmail, credentials != "", len(sshKeys) > 0)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.GitConfigMapName(workspace.Status.WorkspaceId),
+			Namespace: workspace.Namespace,
+			Labels: map[string]string{
+				config.WorkspaceIDLabel: workspace.Status.WorkspaceId,
+			},
+		},
+		Data: map[string]string{
+			gitConfigKey: gitConfig,
+		},
+	}
+	objects := []runtime.Object{configMap}
+	var secret *corev1.Secret
+	if len(secretData) > 0 {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      common.GitCredentialsSecretName(workspace.Status.WorkspaceId),
+				Namespace: workspace.Namespace,
+				Labels: map[string]string{
+					config.WorkspaceIDLabel: workspace.Status.WorkspaceId,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: secretData,
+		}
+		objects = append(objects, secret)
+	} else if err := deleteGitConfig(workspace, clusterAPI, false); err != nil {
+		return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
+	}
+
+	for _, object := range objects {
+		err := controllerutil.SetControllerReference(workspace, object.(metav1.Object), clusterAPI.Scheme)
+		if err != nil {
+			return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Err: err}}
+		}
+	}
+	didChange, err := SyncMutableObjects(objects, clusterAPI.NonCachingClient, clusterAPI.Logger)
+	if err != nil || didChange {
+		return GitConfigProvisioningStatus{ProvisioningStatus: ProvisioningStatus{Requeue: true, Err: err}}
+	}
+
+	return GitConfigProvisioningStatus{
+		ProvisioningStatus: ProvisioningStatus{Continue: true},
+		PodAdditions:       getGitPodAdditions(configMap, secret),
+	}
+}
+
+func getGitPodAdditions(configMap *corev1.ConfigMap, secret *corev1.Secret) *v1alpha1.PodAdditions {
+	hash := sha256.New()
+	hash.Write([]byte(configMap.Data[gitConfigKey]))
+
+	podAdditions := &v1alpha1.PodAdditions{
+		Volumes: []corev1.Volume{
+			{
+				Name: gitConfigVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      gitConfigVolumeName,
+				MountPath: gitConfigMountPath,
+				SubPath:   gitConfigKey,
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	if secret != nil {
+		// The mode of the files depends on the pod's security context and is set by setGitCredentialsMode
+		podAdditions.Volumes = append(podAdditions.Volumes, corev1.Volume{
+			Name: gitCredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		})
+		podAdditions.VolumeMounts = append(podAdditions.VolumeMounts, corev1.VolumeMount{
+			Name:      gitCredentialsVolumeName,
+			MountPath: gitCredentialsMountPath,
+			ReadOnly:  true,
+		})
+
+		var keys []string
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write(secret.Data[key])
+		}
+	}
+
+	podAdditions.Annotations = map[string]string{
+		config.WorkspaceGitConfigHashAnnotation: fmt.Sprintf("%x", hash.Sum(nil))[:16],
+	}
+	return podAdditions
+}
+
+// setGitCredentialsMode sets the mode of the files of the git credentials volume, if any, so that the credentials
+// are readable by the containers' user and SSH accepts the private keys. Secret files are owned by root and, if the
+// pod has an fsGroup, by that group. SSH refuses private keys that are owned by the user running it and readable by
+// others, but does not check the mode of keys owned by another user. The files are therefore readable by the group
+// only with an fsGroup and by their owner only when running as root; otherwise, the containers' user can only read
+// them if they are readable by all users.
+func setGitCredentialsMode(podTemplate *corev1.PodTemplateSpec, containerSecurityContext *corev1.SecurityContext) {
+	podSecurityContext := podTemplate.Spec.SecurityContext
+	if podSecurityContext == nil {
+		podSecurityContext = &corev1.PodSecurityContext{}
+	}
+	runAsUser := podSecurityContext.RunAsUser
+	if containerSecurityContext != nil && containerSecurityContext.RunAsUser != nil {
+		runAsUser = containerSecurityContext.RunAsUser
+	}
+	var mode int32
+	switch {
+	case podSecurityContext.FSGroup != nil:
+		mode = 0440
+	case runAsUser != nil && *runAsUser == 0:
+		mode = 0400
+	default:
+		mode = 0444
+	}
+	for _, volume := range podTemplate.Spec.Volumes {
+		if volume.Name == gitCredentialsVolumeName && volume.Secret != nil {
+			volume.Secret.DefaultMode = &mode
+		}
+	}
+}
+
+func getGitConfig(userName, userEmail string, hasCredentials, hasSSHKeys bool) string {
+	var gitConfig strings.Builder
+	if userName != "" || userEmail != "" {
+		gitConfig.WriteString("[user]\n")
+		if userName != "" {
+			gitConfig.WriteString(fmt.Sprintf("\tname = %s\n", quoteGitConfigValue(userName)))
+		}
+		if userEmail != "" {
+			gitConfig.WriteString(fmt.Sprintf("\temail = %s\n", quoteGitConfigValue(userEmail)))
+		}
+	}
+	if hasCredentials {
+		gitConfig.WriteString("[credential]\n")
+		gitConfig.WriteString(fmt.Sprintf("\thelper = store --file %s\n", path.Join(gitCredentialsMountPath, gitCredentialsKey)))
+	}
+	if hasSSHKeys {
+		gitConfig.WriteString("[core]\n")
+		gitConfig.WriteString(fmt.Sprintf("\tsshCommand = ssh -F %s\n", path.Join(gitCredentialsMountPath, sshConfigKey)))
+	}
+	return gitConfig.String()
+}
+
+// quoteGitConfigValue quotes a value for a git config file, so that e.g. '#' and ';' are not taken as the start of a
+// comment. The value must not contain newlines or other control characters, which cannot be represented in a
+// quoted string.
+func quoteGitConfigValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// getSSHConfig returns the SSH client configuration using all SSH keys. Host keys are always checked against the
+// known_hosts entries from the Secrets, so that connections to git servers cannot be intercepted.
+func getSSHConfig(sshKeys map[string][]byte) string {
+	var keyNames []string
+	for keyName := range sshKeys {
+		keyNames = append(keyNames, keyName)
+	}
+	sort.Strings(keyNames)
+
+	var sshConfig strings.Builder
+	sshConfig.WriteString("Host *\n")
+	for _, keyName := range keyNames {
+		sshConfig.WriteString(fmt.Sprintf("  IdentityFile %s\n", path.Join(gitCredentialsMountPath, keyName)))
+	}
+	sshConfig.WriteString(fmt.Sprintf("  UserKnownHostsFile %s\n", path.Join(gitCredentialsMountPath, sshKnownHostsKey)))
+	sshConfig.WriteString("  StrictHostKeyChecking yes\n")
+	return sshConfig.String()
+}
+
+// deleteGitConfig deletes the git credentials Secret of a workspace and, if withConfigMap is true, its git config
+// ConfigMap, if they exist
+func deleteGitConfig(workspace *v1alpha1.Workspace, clusterAPI ClusterAPI, withConfigMap bool) error {
+	objects := []runtime.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      common.GitCredentialsSecretName(workspace.Status.WorkspaceId),
+			Namespace: workspace.Namespace,
+		}},
+	}
+	if withConfigMap {
+		objects = append(objects, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      common.GitConfigMapName(workspace.Status.WorkspaceId),
+			Namespace: workspace.Namespace,
+		}})
+	}
+	for _, object := range objects {
+		err := clusterAPI.NonCachingClient.Delete(context.TODO(), object)
+		if err == nil {
+			clusterAPI.Logger.Info("Deleted unused workspace git configuration", "name", object.(metav1.Object).GetName())
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// getGitCredentials merges the credentials stored in all Secrets labelled with config.WorkspaceGitCredentialLabel
+// into the format used by the git credential store.
+func getGitCredentials(namespace string, client runtimeClient.Client) (string, error) {
+	secrets, err := listLabelledSecrets(namespace, config.WorkspaceGitCredentialLabel, client)
+	if err != nil {
+		return "", err
+	}
+	var credentials []string
+	for _, secret := range secrets {
+		for _, line := range strings.Split(string(secret.Data[gitCredentialsKey]), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				credentials = append(credentials, line)
+			}
+		}
+	}
+	if len(credentials) == 0 {
+		return "", nil
+	}
+	return strings.Join(credentials, "\n") + "\n", nil
+}
+
+// getGitSSHKeys collects the private keys and known hosts stored in all Secrets labelled with
+// config.WorkspaceGitSSHKeyLabel. Keys are returned as a map of file name to key.
+func getGitSSHKeys(namespace string, client runtimeClient.Client) (sshKeys map[string][]byte, knownHosts string, err error) {
+	secrets, err := listLabelledSecrets(namespace, config.WorkspaceGitSSHKeyLabel, client)
+	if err != nil {
+		return nil, "", err
+	}
+	sshKeys = map[string][]byte{}
+	var knownHostsEntries []string
+	for _, secret := range secrets {
+		privateKey, ok := secret.Data[corev1.SSHAuthPrivateKey]
+		if !ok {
+			return nil, "", fmt.Errorf("secret %s is labelled as git SSH key but does not contain key %s", secret.Name, corev1.SSHAuthPrivateKey)
+		}
+		sshKeys[sshPrivateKeyPrefix+secret.Name] = privateKey
+		if entries := strings.TrimSpace(string(secret.Data[sshKnownHostsKey])); entries != "" {
+			knownHostsEntries = append(knownHostsEntries, entries)
+		}
+	}
+	if len(knownHostsEntries) > 0 {
+		knownHosts = strings.Join(knownHostsEntries, "\n") + "\n"
+	}
+	return sshKeys, knownHosts, nil
+}
+
+func listLabelledSecrets(namespace, label string, client runtimeClient.Client) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	err := client.List(context.TODO(), secrets, runtimeClient.InNamespace(namespace), runtimeClient.MatchingLabels{label: "true"})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(secrets.Items, func(i, j int) bool {
+		return secrets.Items[i].Name < secrets.Items[j].Name
+	})
+	return secrets.Items, nil
+}
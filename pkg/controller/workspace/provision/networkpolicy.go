@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"context"
+	"sort"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SyncNetworkPolicy provisions the NetworkPolicy of a workspace, which isolates the workspace pod from other pods.
+// Ingress is only allowed from the workspace pod itself, from the namespaces of ingress controllers or routers to
+// public endpoints and to the ports of containers added by the workspace routing (e.g. the OpenShift OAuth proxy), and
+// from other workspaces in the namespace to discoverable endpoints with the 'shared' attribute. If NetworkPolicies are
+// disabled in the controller config, the workspace's NetworkPolicy is removed.
+func SyncNetworkPolicy(
+	workspace *v1alpha1.Workspace,
+	components []v1alpha1.ComponentDescription,
+	routingPodAdditions *v1alpha1.PodAdditions,
+	clusterAPI ClusterAPI) ProvisioningStatus {
+
+	policyName := common.NetworkPolicyName(workspace.Status.WorkspaceId)
+	if config.ControllerCfg.GetWorkspaceNetworkPolicyEnabled() != "true" {
+		err := deleteNetworkPolicy(policyName, workspace.Namespace, clusterAPI)
+		return ProvisioningStatus{Continue: err == nil, Err: err}
+	}
+
+	ingressNamespaces, err := config.ControllerCfg.GetWorkspaceNetworkPolicyIngressNamespaces()
+	if err != nil {
+		return ProvisioningStatus{Err: err}
+	}
+	policy := getSpecNetworkPolicy(workspace, policyName, components, routingPodAdditions, ingressNamespaces)
+	err = controllerutil.SetControllerReference(workspace, policy, clusterAPI.Scheme)
+	if err != nil {
+		return ProvisioningStatus{Err: err}
+	}
+	didChange, err := SyncMutableObjects([]runtime.Object{policy}, clusterAPI.Client, clusterAPI.Logger)
+	if err != nil || didChange {
+		return ProvisioningStatus{Requeue: true, Err: err}
+	}
+	return ProvisioningStatus{Continue: true}
+}
+
+func getSpecNetworkPolicy(
+	workspace *v1alpha1.Workspace,
+	policyName string,
+	components []v1alpha1.ComponentDescription,
+	routingPodAdditions *v1alpha1.PodAdditions,
+	ingressNamespaces *metav1.LabelSelector) *networkingv1.NetworkPolicy {
+
+	workspaceId := workspace.Status.WorkspaceId
+	var publicPorts, sharedPorts []int
+	for _, component := range components {
+		for _, endpoint := range component.ComponentMetadata.Endpoints {
+			if endpoint.Attributes[v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE] == "true" {
+				publicPorts = append(publicPorts, int(endpoint.Port))
+			}
+			if endpoint.Attributes[v1alpha1.DISCOVERABLE_ATTRIBUTE] == "true" &&
+				endpoint.Attributes[v1alpha1.SHARED_ENDPOINT_ATTRIBUTE] == "true" {
+				sharedPorts = append(sharedPorts, int(endpoint.Port))
+			}
+		}
+	}
+	if routingPodAdditions != nil {
+		for _, container := range routingPodAdditions.Containers {
+			for _, port := range container.Ports {
+				publicPorts = append(publicPorts, int(port.ContainerPort))
+			}
+		}
+	}
+
+	ingress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{config.WorkspaceIDLabel: workspaceId},
+					},
+				},
+			},
+		},
+	}
+	if len(publicPorts) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: ingressNamespaces}},
+			Ports: getNetworkPolicyPorts(publicPorts),
+		})
+	}
+	if len(sharedPorts) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: config.WorkspaceIDLabel, Operator: metav1.LabelSelectorOpExists},
+						},
+					},
+				},
+			},
+			Ports: getNetworkPolicyPorts(sharedPorts),
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      policyName,
+			Namespace: workspace.Namespace,
+			Labels: map[string]string{
+				config.WorkspaceIDLabel: workspaceId,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{config.WorkspaceIDLabel: workspaceId},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
+
+// getNetworkPolicyPorts returns the sorted and deduplicated TCP ports of a NetworkPolicy rule, so that the
+// NetworkPolicy does not change when components are reordered
+func getNetworkPolicyPorts(ports []int) []networkingv1.NetworkPolicyPort {
+	sort.Ints(ports)
+	var policyPorts []networkingv1.NetworkPolicyPort
+	for idx, port := range ports {
+		if idx > 0 && ports[idx-1] == port {
+			continue
+		}
+		protocol := corev1.ProtocolTCP
+		policyPort := intstr.FromInt(port)
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &policyPort,
+		})
+	}
+	return policyPorts
+}
+
+func deleteNetworkPolicy(name, namespace string, clusterAPI ClusterAPI) error {
+	policy := &networkingv1.NetworkPolicy{}
+	err := clusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, policy)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	clusterAPI.Logger.Info("Deleting workspace NetworkPolicy")
+	err = clusterAPI.Client.Delete(context.TODO(), policy)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
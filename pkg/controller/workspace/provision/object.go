@@ -19,6 +19,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/go-logr/logr"
@@ -31,7 +33,12 @@ import (
 
 // Map to store diff options for each type we're handling.
 var diffOpts = map[reflect.Type]cmp.Options{
-	reflect.TypeOf(rbacv1.Role{}): {cmpopts.IgnoreFields(rbacv1.Role{}, "TypeMeta", "ObjectMeta")},
+	reflect.TypeOf(rbacv1.Role{}):      {cmpopts.IgnoreFields(rbacv1.Role{}, "TypeMeta", "ObjectMeta")},
+	reflect.TypeOf(corev1.ConfigMap{}): {cmpopts.IgnoreFields(corev1.ConfigMap{}, "TypeMeta", "ObjectMeta")},
+	reflect.TypeOf(corev1.Secret{}):    {cmpopts.IgnoreFields(corev1.Secret{}, "TypeMeta", "ObjectMeta")},
+	reflect.TypeOf(networkingv1.NetworkPolicy{}): {
+		cmpopts.IgnoreFields(networkingv1.NetworkPolicy{}, "TypeMeta", "ObjectMeta"),
+	},
 	reflect.TypeOf(rbacv1.RoleBinding{}): {
 		cmpopts.IgnoreFields(rbacv1.RoleBinding{}, "TypeMeta", "ObjectMeta"),
 		cmpopts.IgnoreFields(rbacv1.RoleRef{}, "APIGroup"),
@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetWorkspaceResources computes the total resources required by the workspace pod built from podAdditions. As init
+// containers run one at a time before regular containers are started, the effective value for each resource is the
+// larger of the sum over all containers and the maximum over all init containers, as done by the Kubernetes scheduler.
+func GetWorkspaceResources(podAdditions []v1alpha1.PodAdditions) *corev1.ResourceRequirements {
+	containerRequests, containerLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	initRequests, initLimits := corev1.ResourceList{}, corev1.ResourceList{}
+	for _, additions := range podAdditions {
+		for _, container := range additions.Containers {
+			addResourceList(containerRequests, container.Resources.Requests)
+			addResourceList(containerLimits, container.Resources.Limits)
+		}
+		for _, container := range additions.InitContainers {
+			maxResourceList(initRequests, container.Resources.Requests)
+			maxResourceList(initLimits, container.Resources.Limits)
+		}
+	}
+	maxResourceList(containerRequests, initRequests)
+	maxResourceList(containerLimits, initLimits)
+
+	resources := &corev1.ResourceRequirements{}
+	if len(containerRequests) > 0 {
+		resources.Requests = containerRequests
+	}
+	if len(containerLimits) > 0 {
+		resources.Limits = containerLimits
+	}
+	return resources
+}
+
+func addResourceList(total, toAdd corev1.ResourceList) {
+	for name, quantity := range toAdd {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(total, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -136,6 +136,7 @@ func getSpecRouting(
 			},
 		},
 	}
+	routing.Spec.AllowedUsers, routing.Spec.AllowedGroups = getAllowedUsersAndGroups(workspace)
 	err := controllerutil.SetControllerReference(workspace, routing, scheme)
 	if err != nil {
 		return nil, err
@@ -144,6 +145,20 @@ func getSpecRouting(
 	return routing, nil
 }
 
+// getAllowedUsersAndGroups returns the users and groups allowed to access the workspace's endpoints: its creator and
+// the users and groups it is shared with. Nothing is returned for workspaces whose creator's username is unknown and
+// that are not shared, so that their endpoints remain accessible to any authenticated user.
+func getAllowedUsersAndGroups(workspace *v1alpha1.Workspace) (users, groups []string) {
+	if creator, ok := workspace.Annotations[config.WorkspaceCreatorUsernameAnnotation]; ok && creator != "" {
+		users = append(users, creator)
+	}
+	if workspace.Spec.SharedWith != nil {
+		users = append(users, workspace.Spec.SharedWith.Users...)
+		groups = append(groups, workspace.Spec.SharedWith.Groups...)
+	}
+	return users, groups
+}
+
 func getClusterRouting(name string, namespace string, client runtimeClient.Client) (*v1alpha1.WorkspaceRouting, error) {
 	routing := &v1alpha1.WorkspaceRouting{}
 	namespacedName := types.NamespacedName{
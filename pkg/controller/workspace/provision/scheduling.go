@@ -0,0 +1,56 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyScheduling sets the scheduling settings of a workspace pod from the workspace spec and the defaults from the
+// controller config. The workspace's node selector is merged with the default one and its tolerations are added to the
+// default ones; other settings of the workspace replace the defaults.
+func applyScheduling(workspace *v1alpha1.Workspace, podSpec *corev1.PodSpec) error {
+	defaults, err := config.ControllerCfg.GetSchedulingDefaults(workspace.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if len(defaults.NodeSelector) > 0 || len(workspace.Spec.NodeSelector) > 0 {
+		podSpec.NodeSelector = map[string]string{}
+		for key, value := range defaults.NodeSelector {
+			podSpec.NodeSelector[key] = value
+		}
+		for key, value := range workspace.Spec.NodeSelector {
+			podSpec.NodeSelector[key] = value
+		}
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, defaults.Tolerations...)
+	podSpec.Tolerations = append(podSpec.Tolerations, workspace.Spec.Tolerations...)
+
+	podSpec.Affinity = defaults.Affinity
+	if workspace.Spec.Affinity != nil {
+		podSpec.Affinity = workspace.Spec.Affinity.DeepCopy()
+	}
+	podSpec.PriorityClassName = defaults.PriorityClassName
+	if workspace.Spec.PriorityClassName != "" {
+		podSpec.PriorityClassName = workspace.Spec.PriorityClassName
+	}
+	podSpec.RuntimeClassName = defaults.RuntimeClassName
+	if workspace.Spec.RuntimeClassName != nil {
+		runtimeClassName := *workspace.Spec.RuntimeClassName
+		podSpec.RuntimeClassName = &runtimeClassName
+	}
+	return nil
+}
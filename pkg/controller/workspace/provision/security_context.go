@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package provision
+
+import (
+	"encoding/json"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applySecurityContext sets the security contexts of a workspace pod and its containers from the defaults in the
+// controller config and the overrides in the workspace spec. Fields set in the workspace spec take precedence over
+// the defaults, and fields set by a container itself take precedence over both. The seccomp profile from the
+// controller config is set as a pod annotation, since the API version in use has no field for it. As the mode needed
+// for the git credentials depends on the security contexts, it is set here.
+func applySecurityContext(workspace *v1alpha1.Workspace, podTemplate *corev1.PodTemplateSpec) error {
+	podSecurityContext, err := config.ControllerCfg.GetPodSecurityContext()
+	if err != nil {
+		return err
+	}
+	if err := mergeSecurityContext(podSecurityContext, workspace.Spec.PodSecurityContext); err != nil {
+		return err
+	}
+	podTemplate.Spec.SecurityContext = podSecurityContext
+
+	defaultContainerContext, err := config.ControllerCfg.GetContainerSecurityContext()
+	if err != nil {
+		return err
+	}
+	if err := mergeSecurityContext(defaultContainerContext, workspace.Spec.ContainerSecurityContext); err != nil {
+		return err
+	}
+	for _, containers := range [][]corev1.Container{podTemplate.Spec.InitContainers, podTemplate.Spec.Containers} {
+		for idx := range containers {
+			containerContext := defaultContainerContext.DeepCopy()
+			if err := mergeSecurityContext(containerContext, containers[idx].SecurityContext); err != nil {
+				return err
+			}
+			containers[idx].SecurityContext = containerContext
+		}
+	}
+
+	setGitCredentialsMode(podTemplate, defaultContainerContext)
+
+	if profile := config.ControllerCfg.GetSeccompProfile(); profile != "" {
+		if podTemplate.Annotations == nil {
+			podTemplate.Annotations = map[string]string{}
+		}
+		podTemplate.Annotations[corev1.SeccompPodAnnotationKey] = profile
+	}
+	return nil
+}
+
+// mergeSecurityContext sets the fields of a pod or container security context that are set in overrides. Nested
+// structs are merged recursively, while lists replace those of the security context.
+func mergeSecurityContext(securityContext, overrides interface{}) error {
+	overridesJSON, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	// Unmarshalling into a populated struct only sets the fields present in the JSON, and overrides have no empty
+	// fields as all fields of security contexts are optional.
+	return json.Unmarshal(overridesJSON, securityContext)
+}
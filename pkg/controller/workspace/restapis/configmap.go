@@ -36,8 +36,8 @@ var configmapDiffOpts = cmp.Options{
 	cmpopts.IgnoreFields(corev1.ConfigMap{}, "TypeMeta", "ObjectMeta"),
 }
 
-func SyncRestAPIsConfigMap(workspace *v1alpha1.Workspace, components []v1alpha1.ComponentDescription, endpoints map[string]v1alpha1.ExposedEndpointList, clusterAPI provision.ClusterAPI) provision.ProvisioningStatus {
-	specCM, err := getSpecConfigMap(workspace, components, endpoints, clusterAPI.Scheme)
+func SyncRestAPIsConfigMap(workspace *v1alpha1.Workspace, devfile *v1alpha1.DevfileSpec, components []v1alpha1.ComponentDescription, endpoints map[string]v1alpha1.ExposedEndpointList, clusterAPI provision.ClusterAPI) provision.ProvisioningStatus {
+	specCM, err := getSpecConfigMap(workspace, devfile, components, endpoints, clusterAPI.Scheme)
 	if err != nil {
 		return provision.ProvisioningStatus{Err: err}
 	}
@@ -68,14 +68,15 @@ func SyncRestAPIsConfigMap(workspace *v1alpha1.Workspace, components []v1alpha1.
 
 func getSpecConfigMap(
 	workspace *v1alpha1.Workspace,
+	devfile *v1alpha1.DevfileSpec,
 	components []v1alpha1.ComponentDescription,
 	endpoints map[string]v1alpha1.ExposedEndpointList,
 	scheme *k8sRuntime.Scheme) (*corev1.ConfigMap, error) {
-	runtimeJSON, err := constructRuntimeAnnotation(components, endpoints)
+	runtimeJSON, err := constructRuntimeAnnotation(devfile, components, endpoints)
 	if err != nil {
 		return nil, err
 	}
-	devfileYAML, err := getDevfileYaml(workspace.Spec.Devfile)
+	devfileYAML, err := getDevfileYaml(devfile)
 	if err != nil {
 		return nil, err
 	}
@@ -113,19 +114,43 @@ func getClusterConfigMap(name, namespace string, client runtimeClient.Client) (*
 	return cm, err
 }
 
-func getDevfileYaml(devfile v1alpha1.DevfileSpec) (string, error) {
-	devfileYaml, err := yaml.Marshal(devfile)
+func getDevfileYaml(devfile *v1alpha1.DevfileSpec) (string, error) {
+	devfileYaml, err := yaml.Marshal(redactDevfileSecrets(devfile))
 	if err != nil {
 		return "", err
 	}
 	return string(devfileYaml), err
 }
 
-func constructRuntimeAnnotation(components []v1alpha1.ComponentDescription, endpoints map[string]v1alpha1.ExposedEndpointList) (string, error) {
+// redactDevfileSecrets returns a copy of devfile that is safe to be stored in a ConfigMap: environment variables with
+// a source for their value, as well as variables named like a variable sourced from a Secret, e.g. a credential that
+// was meant to be moved to the Secret, never carry a value.
+func redactDevfileSecrets(devfile *v1alpha1.DevfileSpec) *v1alpha1.DevfileSpec {
+	redacted := devfile.DeepCopy()
+	secretEnvNames := map[string]bool{}
+	for _, component := range redacted.Components {
+		for _, env := range component.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				secretEnvNames[env.Name] = true
+			}
+		}
+	}
+	for _, component := range redacted.Components {
+		for idx, env := range component.Env {
+			if env.ValueFrom != nil || secretEnvNames[env.Name] {
+				component.Env[idx].Value = ""
+			}
+		}
+	}
+	return redacted
+}
+
+func constructRuntimeAnnotation(devfile *v1alpha1.DevfileSpec, components []v1alpha1.ComponentDescription, endpoints map[string]v1alpha1.ExposedEndpointList) (string, error) {
 	defaultEnv := "default"
 
 	machines := getMachinesAnnotation(components, endpoints)
 	commands := getWorkspaceCommands(components)
+	commands = append(commands, getCompositeCommands(devfile)...)
 
 	runtime := v1alpha1.CheWorkspaceRuntime{
 		ActiveEnv: defaultEnv,
@@ -171,3 +196,31 @@ func getWorkspaceCommands(components []v1alpha1.ComponentDescription) []v1alpha1
 	}
 	return commands
 }
+
+// getCompositeCommands returns the runtime commands for composite devfile commands and devfile commands with several
+// actions. The latter refer to the runtime commands contributed for their actions, which are named using
+// common.CommandActionName.
+func getCompositeCommands(devfile *v1alpha1.DevfileSpec) []v1alpha1.CheWorkspaceCommand {
+	var commands []v1alpha1.CheWorkspaceCommand
+	for _, command := range devfile.Commands {
+		var commandNames []string
+		switch {
+		case len(command.Commands) > 0:
+			commandNames = command.Commands
+		case len(command.Actions) > 1:
+			for idx := range command.Actions {
+				commandNames = append(commandNames, common.CommandActionName(command.Name, idx, len(command.Actions)))
+			}
+		default:
+			continue
+		}
+		commands = append(commands, v1alpha1.CheWorkspaceCommand{
+			Name:       command.Name,
+			Type:       config.CompositeCommandType,
+			Attributes: command.Attributes,
+			Commands:   commandNames,
+			Parallel:   command.Parallel,
+		})
+	}
+	return commands
+}
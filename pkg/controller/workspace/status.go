@@ -26,6 +26,7 @@ import (
 	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	"github.com/che-incubator/che-workspace-operator/pkg/controller/workspace/provision"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeclock "k8s.io/apimachinery/pkg/util/clock"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -46,6 +47,10 @@ var healthHttpClient = &http.Client{
 // Parameters for result and error are returned unmodified, unless error is nil and another error is encountered while
 // updating the status.
 func (r *ReconcileWorkspace) updateWorkspaceStatus(workspace *v1alpha1.Workspace, logger logr.Logger, status *currentStatus, reconcileResult reconcile.Result, reconcileError error) (reconcile.Result, error) {
+	if status.Phase == v1alpha1.WorkspaceStatusFailed {
+		// Failed workspaces release their quota, so that they are only admitted again when they are restarted
+		status.Conditions = removeConditionType(status.Conditions, v1alpha1.WorkspaceQuotaAvailable)
+	}
 	workspace.Status.Phase = status.Phase
 	currTransitionTime := metav1.Time{Time: clock.Now()}
 	for _, conditionType := range status.Conditions {
@@ -54,6 +59,7 @@ func (r *ReconcileWorkspace) updateWorkspaceStatus(workspace *v1alpha1.Workspace
 			if condition.Type == conditionType && condition.LastTransitionTime.Before(&currTransitionTime) {
 				workspace.Status.Conditions[idx].LastTransitionTime = currTransitionTime
 				workspace.Status.Conditions[idx].Status = corev1.ConditionTrue
+				workspace.Status.Conditions[idx].Message = ""
 				conditionExists = true
 				break
 			}
@@ -66,6 +72,26 @@ func (r *ReconcileWorkspace) updateWorkspaceStatus(workspace *v1alpha1.Workspace
 			})
 		}
 	}
+	for conditionType, message := range status.FailedConditions {
+		conditionExists := false
+		for idx, condition := range workspace.Status.Conditions {
+			if condition.Type == conditionType {
+				workspace.Status.Conditions[idx].LastTransitionTime = currTransitionTime
+				workspace.Status.Conditions[idx].Status = corev1.ConditionFalse
+				workspace.Status.Conditions[idx].Message = message
+				conditionExists = true
+				break
+			}
+		}
+		if !conditionExists {
+			workspace.Status.Conditions = append(workspace.Status.Conditions, v1alpha1.WorkspaceCondition{
+				Type:               conditionType,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: currTransitionTime,
+				Message:            message,
+			})
+		}
+	}
 	for idx, condition := range workspace.Status.Conditions {
 		if condition.LastTransitionTime.Before(&currTransitionTime) {
 			workspace.Status.Conditions[idx].LastTransitionTime = currTransitionTime
@@ -86,6 +112,16 @@ func (r *ReconcileWorkspace) updateWorkspaceStatus(workspace *v1alpha1.Workspace
 	return reconcileResult, reconcileError
 }
 
+func removeConditionType(conditions []v1alpha1.WorkspaceConditionType, conditionType v1alpha1.WorkspaceConditionType) []v1alpha1.WorkspaceConditionType {
+	var filtered []v1alpha1.WorkspaceConditionType
+	for _, condition := range conditions {
+		if condition != conditionType {
+			filtered = append(filtered, condition)
+		}
+	}
+	return filtered
+}
+
 func syncWorkspaceIdeURL(workspace *v1alpha1.Workspace, exposedEndpoints map[string]v1alpha1.ExposedEndpointList, clusterAPI provision.ClusterAPI) (ok bool, err error) {
 	ideUrl := getIdeUrl(exposedEndpoints)
 
@@ -97,6 +133,17 @@ func syncWorkspaceIdeURL(workspace *v1alpha1.Workspace, exposedEndpoints map[str
 	return false, err
 }
 
+func syncWorkspaceResources(workspace *v1alpha1.Workspace, podAdditions []v1alpha1.PodAdditions, clusterAPI provision.ClusterAPI) (ok bool, err error) {
+	resources := provision.GetWorkspaceResources(podAdditions)
+
+	if equality.Semantic.DeepEqual(workspace.Status.Resources, resources) {
+		return true, nil
+	}
+	workspace.Status.Resources = resources
+	err = clusterAPI.Client.Status().Update(context.TODO(), workspace)
+	return false, err
+}
+
 func checkServerStatus(workspace *v1alpha1.Workspace) (ok bool, err error) {
 	ideUrl := workspace.Status.IdeUrl
 	if ideUrl == "" {
@@ -25,14 +25,21 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/che-incubator/che-workspace-operator/internal/cluster"
+	internalcontroller "github.com/che-incubator/che-workspace-operator/internal/controller"
+	"github.com/che-incubator/che-workspace-operator/internal/policy"
+	"github.com/che-incubator/che-workspace-operator/internal/quota"
+	"github.com/che-incubator/che-workspace-operator/pkg/adaptor"
 	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	workspacev1alpha1 "github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	"github.com/che-incubator/che-workspace-operator/pkg/controller/workspace/provision"
 	"github.com/che-incubator/che-workspace-operator/pkg/controller/workspace/restapis"
+	"github.com/che-incubator/che-workspace-operator/pkg/devfile"
 	"github.com/google/uuid"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,9 +53,18 @@ import (
 
 var log = logf.Log.WithName("controller_workspace")
 
+// restartPendingMessage describes the SpecApplied condition of workspaces whose restart is pending confirmation
+var restartPendingMessage = fmt.Sprintf("Workspace restart is pending: annotate the workspace with %s=true to apply changes", config.WorkspaceConfirmRestartAnnotation)
+
+// quotaRequeueInterval is the interval at which workspaces whose start is queued because of exceeded quotas are
+// checked again
+const quotaRequeueInterval = 30 * time.Second
+
 type currentStatus struct {
 	// List of condition types that are true for the current workspace
 	Conditions []workspacev1alpha1.WorkspaceConditionType
+	// Condition types that are false for the current workspace, mapped to messages describing why
+	FailedConditions map[workspacev1alpha1.WorkspaceConditionType]string
 	// Current workspace phase
 	Phase workspacev1alpha1.WorkspacePhase
 }
@@ -61,7 +77,7 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) *ReconcileWorkspace {
-	return &ReconcileWorkspace{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+	return &ReconcileWorkspace{client: mgr.GetClient(), apiReader: mgr.GetAPIReader(), scheme: mgr.GetScheme()}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -126,6 +142,56 @@ func add(mgr manager.Manager, r *ReconcileWorkspace) error {
 		OwnerType:    &workspacev1alpha1.Workspace{},
 	})
 
+	// Watch for changes to workspace NetworkPolicies, to revert changes by users
+	err = c.Watch(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &workspacev1alpha1.Workspace{},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to automounted Secrets and ConfigMaps, as well as git credentials, and requeue all workspaces
+	// in their namespace. Only labelled objects are watched, to avoid caching all Secrets and ConfigMaps in the cluster.
+	namespaceHandler := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: workspacesInNamespaceMapper(mgr.GetClient()),
+	}
+	secretSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.SecretInformer, config.WorkspaceMountLabel+"=true", config.WorkspaceGitCredentialLabel+"=true", config.WorkspaceGitSSHKeyLabel+"=true")
+	if err != nil {
+		return err
+	}
+	configMapSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.ConfigMapInformer, config.WorkspaceMountLabel+"=true")
+	if err != nil {
+		return err
+	}
+	for _, src := range append(secretSources, configMapSources...) {
+		if err := c.Watch(src, namespaceHandler); err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to workspace pods, to report failures of lifecycle commands and wait for pods to terminate
+	podSources, err := internalcontroller.LabelSelectedSources(mgr, internalcontroller.PodInformer, config.WorkspaceIDLabel)
+	if err != nil {
+		return err
+	}
+	for _, src := range podSources {
+		err := c.Watch(src, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(workspacePodMapper),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to workspace templates and requeue the workspaces that use them
+	err = c.Watch(&source.Kind{Type: &workspacev1alpha1.WorkspaceTemplate{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: workspacesUsingTemplateMapper(mgr.GetClient()),
+	})
+	if err != nil {
+		return err
+	}
+
 	// Redirect standard logging to the reconcile's log
 	// Necessary as e.g. the plugin broker logs to stdout
 	origLog.SetOutput(r)
@@ -141,7 +207,9 @@ type ReconcileWorkspace struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
-	scheme *runtime.Scheme
+	// Reader that reads directly from the API server, for objects that should not be cached
+	apiReader client.Reader
+	scheme    *runtime.Scheme
 }
 
 // Enable redirecting standard log output to the controller's log
@@ -157,6 +225,11 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 	reqLogger.Info("Reconciling Workspace")
 	clusterAPI := provision.ClusterAPI{
 		Client: r.client,
+		NonCachingClient: client.DelegatingClient{
+			Reader:       r.apiReader,
+			Writer:       r.client,
+			StatusClient: r.client,
+		},
 		Scheme: r.scheme,
 		Logger: reqLogger,
 	}
@@ -204,15 +277,87 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 		return r.updateWorkspaceStatus(workspace, reqLogger, &reconcileStatus, reconcileResult, err)
 	}()
 
+	// Admitted workspaces keep their quota while they are started. Quotas are checked before any workspace objects are
+	// created; if the memory of the workspace is not computed yet, they are checked again once it is.
+	quotaAdmitted := quota.IsAdmitted(workspace)
+	if quotaAdmitted {
+		reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceQuotaAvailable)
+	}
+
 	immutable := workspace.Annotations[config.WorkspaceImmutableAnnotation]
 	if immutable == "true" && config.ControllerCfg.GetWebhooksEnabled() != "true" {
 		reqLogger.Info("Workspace is configured as immutable but webhooks are not enabled.")
 		reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+		reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+			workspacev1alpha1.WorkspaceReady: "Workspace is configured as immutable, which requires webhooks to be enabled",
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// Scheduling and security context settings are checked by the webhooks too, but webhooks may be disabled
+	policyErr := policy.CheckSchedulingAllowed(nil, workspace)
+	if policyErr == nil {
+		policyErr = policy.CheckSecurityContextAllowed(nil, workspace)
+	}
+	if policyErr != nil {
+		reqLogger.Info("Workspace start failed: workspace spec is not allowed", "reason", policyErr.Error())
+		reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+		reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+			workspacev1alpha1.WorkspaceReady: "Workspace spec is not allowed: " + policyErr.Error(),
+		}
+		return reconcile.Result{}, nil
+	}
+
+	devfileSpec, err := devfile.ResolveWorkspaceDevfile(r.client, workspace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Workspace is reconciled again once the template is created
+			reqLogger.Info("Waiting on workspace template", "error", err.Error())
+			return reconcile.Result{}, nil
+		}
+		if _, isAPIError := err.(errors.APIStatus); isAPIError {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Workspace start failed: invalid devfile", "error", err.Error())
+		reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+		reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+			workspacev1alpha1.WorkspaceComponentsReady: "Invalid devfile: " + err.Error(),
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !quotaAdmitted {
+		if result, exceeded, err := r.checkQuota(workspace, &reconcileStatus, reqLogger); exceeded || err != nil {
+			return result, err
+		}
+		if quota.IsMemoryKnown(workspace) {
+			quotaAdmitted = true
+			reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceQuotaAvailable)
+		}
+	}
+
+	// Changes to a running workspace that waits for its restart to be confirmed are not applied to any of its objects
+	restartPending, err := provision.IsRestartPending(workspace, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if restartPending {
+		reqLogger.Info("Workspace restart is pending confirmation")
+		for _, condition := range workspace.Status.Conditions {
+			if condition.Status == corev1.ConditionTrue && condition.Type != workspacev1alpha1.WorkspaceSpecApplied &&
+				condition.Type != workspacev1alpha1.WorkspaceQuotaAvailable {
+				reconcileStatus.Conditions = append(reconcileStatus.Conditions, condition.Type)
+			}
+		}
+		reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+			workspacev1alpha1.WorkspaceSpecApplied: restartPendingMessage,
+		}
+		reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusRunning
 		return reconcile.Result{}, nil
 	}
 
 	// Step one: Create components, and wait for their states to be ready.
-	componentsStatus := provision.SyncComponentsToCluster(workspace, clusterAPI)
+	componentsStatus := provision.SyncComponentsToCluster(workspace, devfileSpec, clusterAPI)
 	if !componentsStatus.Continue {
 		reqLogger.Info("Waiting on components to be ready")
 		return reconcile.Result{Requeue: componentsStatus.Requeue}, componentsStatus.Err
@@ -221,7 +366,7 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 	reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceComponentsReady)
 
 	// Only add che rest apis if theia editor is present in the devfile
-	if isCheRestApisRequired(workspace.Spec.Devfile.Components) {
+	if isCheRestApisRequired(devfileSpec.Components) {
 		// TODO: first half of provisioning rest-apis
 		cheRestApisComponent := restapis.GetCheRestApisComponent(workspace.Name, workspace.Status.WorkspaceId, workspace.Namespace)
 		componentDescriptions = append(componentDescriptions, cheRestApisComponent)
@@ -259,9 +404,16 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	err = adaptor.SubstituteEndpointURLs(componentDescriptions, routingStatus.ExposedEndpoints)
+	if err != nil {
+		reqLogger.Info("Workspace start failed")
+		reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+		return reconcile.Result{}, err
+	}
+
 	// Step three: setup che-rest-apis configmap
-	if isCheRestApisRequired(workspace.Spec.Devfile.Components) {
-		configMapStatus := restapis.SyncRestAPIsConfigMap(workspace, componentDescriptions, routingStatus.ExposedEndpoints, clusterAPI)
+	if isCheRestApisRequired(devfileSpec.Components) {
+		configMapStatus := restapis.SyncRestAPIsConfigMap(workspace, devfileSpec, componentDescriptions, routingStatus.ExposedEndpoints, clusterAPI)
 		if !configMapStatus.Continue {
 			if configMapStatus.FailStartup {
 				reqLogger.Info("Workspace start failed")
@@ -273,6 +425,12 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 		}
 	}
 
+	networkPolicyStatus := provision.SyncNetworkPolicy(workspace, componentDescriptions, routingStatus.PodAdditions, clusterAPI)
+	if !networkPolicyStatus.Continue {
+		reqLogger.Info("Waiting for workspace NetworkPolicy")
+		return reconcile.Result{Requeue: networkPolicyStatus.Requeue}, networkPolicyStatus.Err
+	}
+
 	// Step four: Collect all workspace deployment contributions
 	routingPodAdditions := routingStatus.PodAdditions
 	var podAdditions []workspacev1alpha1.PodAdditions
@@ -283,6 +441,22 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 		podAdditions = append(podAdditions, *routingPodAdditions)
 	}
 
+	statusOk, err = syncWorkspaceResources(workspace, podAdditions, clusterAPI)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !statusOk {
+		reqLogger.Info("Updating workspace resources")
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	if !quotaAdmitted {
+		if result, exceeded, err := r.checkQuota(workspace, &reconcileStatus, reqLogger); exceeded || err != nil {
+			return result, err
+		}
+		reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceQuotaAvailable)
+	}
+
 	// Step five: Prepare workspace ServiceAccount
 	saAnnotations := map[string]string{}
 	if routingPodAdditions != nil {
@@ -301,7 +475,23 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 	serviceAcctName := serviceAcctStatus.ServiceAccountName
 	reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceServiceAccountReady)
 
+	gitConfigStatus := provision.SyncGitConfig(workspace, clusterAPI)
+	if !gitConfigStatus.Continue {
+		if gitConfigStatus.FailStartup {
+			reqLogger.Info("Workspace start failed: invalid git configuration", "reason", gitConfigStatus.Message)
+			reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+			reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+				workspacev1alpha1.WorkspaceReady: "Invalid git configuration: " + gitConfigStatus.Message,
+			}
+			return reconcile.Result{}, gitConfigStatus.Err
+		}
+		reqLogger.Info("Waiting for workspace git configuration")
+		return reconcile.Result{Requeue: gitConfigStatus.Requeue}, gitConfigStatus.Err
+	}
+	podAdditions = append(podAdditions, *gitConfigStatus.PodAdditions)
+
 	// Step five: Create deployment and wait for it to be ready
+	hasPostStartCommands := devfileSpec.Events != nil && len(devfileSpec.Events.PostStart) > 0
 	deploymentStatus := provision.SyncDeploymentToCluster(workspace, podAdditions, componentDescriptions, serviceAcctName, clusterAPI)
 	if !deploymentStatus.Continue {
 		if deploymentStatus.FailStartup {
@@ -309,9 +499,46 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 			reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
 			return reconcile.Result{}, deploymentStatus.Err
 		}
+		if hasPostStartCommands {
+			postStartFailure, err := r.getPostStartFailure(workspace)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if postStartFailure != "" {
+				reqLogger.Info("Workspace start failed: postStart commands failed")
+				reconcileStatus.Phase = workspacev1alpha1.WorkspaceStatusFailed
+				reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+					workspacev1alpha1.WorkspacePostStartCommandsSucceeded: postStartFailure,
+				}
+				return reconcile.Result{}, nil
+			}
+		}
+		if deploymentStatus.Restarting {
+			reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+				workspacev1alpha1.WorkspaceSpecApplied: "Workspace is restarting to apply changes",
+			}
+		}
 		reqLogger.Info("Waiting on deployment to be ready")
 		return reconcile.Result{Requeue: deploymentStatus.Requeue}, deploymentStatus.Err
 	}
+	if deploymentStatus.RestartPending {
+		reconcileStatus.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+			workspacev1alpha1.WorkspaceSpecApplied: restartPendingMessage,
+		}
+	} else {
+		if _, ok := workspace.Annotations[config.WorkspaceConfirmRestartAnnotation]; ok {
+			// The confirmation is consumed once changes are applied
+			patch := client.MergeFrom(workspace.DeepCopy())
+			delete(workspace.Annotations, config.WorkspaceConfirmRestartAnnotation)
+			if err := r.client.Patch(context.TODO(), workspace, patch); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceSpecApplied)
+	}
+	if hasPostStartCommands {
+		reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspacePostStartCommandsSucceeded)
+	}
 	reconcileStatus.Conditions = append(reconcileStatus.Conditions, workspacev1alpha1.WorkspaceReady)
 
 	serverReady, err := checkServerStatus(workspace)
@@ -325,6 +552,26 @@ func (r *ReconcileWorkspace) Reconcile(request reconcile.Request) (reconcileResu
 	return reconcile.Result{}, nil
 }
 
+// checkQuota checks whether starting a workspace exceeds the quotas of its namespace or creator. If it does, the start
+// is queued or failed depending on the quota policy, and the result to return from reconciling is returned. Workspaces
+// are read from the API server, as the cache may not contain workspaces that were admitted just before.
+func (r *ReconcileWorkspace) checkQuota(workspace *workspacev1alpha1.Workspace, status *currentStatus, logger logr.Logger) (result reconcile.Result, exceeded bool, err error) {
+	message, err := quota.Check(context.TODO(), r.apiReader, workspace)
+	if err != nil || message == "" {
+		return reconcile.Result{}, false, err
+	}
+	status.FailedConditions = map[workspacev1alpha1.WorkspaceConditionType]string{
+		workspacev1alpha1.WorkspaceQuotaAvailable: message,
+	}
+	if config.ControllerCfg.GetQuotaPolicy() == config.QuotaPolicyQueue {
+		logger.Info("Workspace start queued: quota exceeded", "reason", message)
+		return reconcile.Result{RequeueAfter: quotaRequeueInterval}, true, nil
+	}
+	logger.Info("Workspace start failed: quota exceeded", "reason", message)
+	status.Phase = workspacev1alpha1.WorkspaceStatusFailed
+	return reconcile.Result{}, true, nil
+}
+
 func (r *ReconcileWorkspace) stopWorkspace(workspace *workspacev1alpha1.Workspace, logger logr.Logger) (reconcile.Result, error) {
 	workspaceDeployment := &appsv1.Deployment{}
 	namespaceName := types.NamespacedName{
@@ -356,8 +603,18 @@ func (r *ReconcileWorkspace) stopWorkspace(workspace *workspacev1alpha1.Workspac
 	}
 
 	if workspaceDeployment.Status.Replicas == 0 {
-		logger.Info("Workspace stopped")
-		status.Phase = workspacev1alpha1.WorkspaceStatusStopped
+		// Pods are not counted in the deployment's replicas while they terminate, e.g. while running preStop commands
+		pods, err := r.getWorkspacePods(workspace)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.checkPreStopCommands(workspace, pods, status); err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(pods) == 0 {
+			logger.Info("Workspace stopped")
+			status.Phase = workspacev1alpha1.WorkspaceStatusStopped
+		}
 	}
 	return r.updateWorkspaceStatus(workspace, logger, status, reconcile.Result{}, nil)
 }
@@ -378,3 +635,54 @@ func isCheRestApisRequired(components []workspacev1alpha1.ComponentSpec) bool {
 	}
 	return false
 }
+
+// workspacesInNamespaceMapper returns a mapper that enqueues all workspaces in the namespace of an object
+func workspacesInNamespaceMapper(c client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		workspaces := &workspacev1alpha1.WorkspaceList{}
+		err := c.List(context.TODO(), workspaces, client.InNamespace(obj.Meta.GetNamespace()))
+		if err != nil {
+			log.Error(err, "Failed to list workspaces", "namespace", obj.Meta.GetNamespace())
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, workspace := range workspaces.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      workspace.Name,
+					Namespace: workspace.Namespace,
+				},
+			})
+		}
+		return requests
+	}
+}
+
+// workspacesUsingTemplateMapper returns a mapper that enqueues all workspaces that use a workspace template
+func workspacesUsingTemplateMapper(c client.Client) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		// Templates can be used by workspaces in any namespace
+		workspaces := &workspacev1alpha1.WorkspaceList{}
+		err := c.List(context.TODO(), workspaces)
+		if err != nil {
+			log.Error(err, "Failed to list workspaces")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, workspace := range workspaces.Items {
+			templateRef, err := devfile.GetTemplateReference(&workspace)
+			if err != nil || templateRef == nil {
+				continue
+			}
+			if templateRef.Name == obj.Meta.GetName() && templateRef.Namespace == obj.Meta.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      workspace.Name,
+						Namespace: workspace.Namespace,
+					},
+				})
+			}
+		}
+		return requests
+	}
+}
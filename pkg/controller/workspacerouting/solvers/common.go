@@ -28,6 +28,9 @@ type WorkspaceMetadata struct {
 	Namespace     string
 	PodSelector   map[string]string
 	RoutingSuffix string
+	RoutingName   string
+	AllowedUsers  []string
+	AllowedGroups []string
 }
 
 func getDiscoverableServicesForEndpoints(endpoints map[string]v1alpha1.EndpointList, meta WorkspaceMetadata) []corev1.Service {
@@ -0,0 +1,104 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package solvers
+
+import (
+	"encoding/json"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// endpointAccessVerb is the verb on the workspace's WorkspaceRouting that users must be allowed to perform to access
+// the workspace's endpoints. It is not used by any built-in role, so that only the users and groups of the routing
+// are granted it.
+const endpointAccessVerb = "connect"
+
+func isEndpointAccessRestricted(meta WorkspaceMetadata) bool {
+	return len(meta.AllowedUsers) > 0 || len(meta.AllowedGroups) > 0
+}
+
+func endpointAccessName(meta WorkspaceMetadata) string {
+	return meta.WorkspaceId + "-endpoint-access"
+}
+
+// getEndpointAccessReview returns the subject access review that oauth-proxy performs with the token of the user
+// accessing an endpoint, or an empty string if any authenticated user may access the workspace's endpoints.
+func getEndpointAccessReview(meta WorkspaceMetadata) string {
+	if !isEndpointAccessRestricted(meta) {
+		return ""
+	}
+	// Marshalling ResourceAttributes cannot fail
+	review, _ := json.Marshal(authorizationv1.ResourceAttributes{
+		Namespace: meta.Namespace,
+		Verb:      endpointAccessVerb,
+		Group:     v1alpha1.SchemeGroupVersion.Group,
+		Resource:  "workspaceroutings",
+		Name:      meta.RoutingName,
+	})
+	return string(review)
+}
+
+// getEndpointAccessRBAC returns the Role and RoleBinding that allow the routing's users and groups to pass the subject
+// access review returned by getEndpointAccessReview.
+func getEndpointAccessRBAC(meta WorkspaceMetadata) ([]rbacv1.Role, []rbacv1.RoleBinding) {
+	if !isEndpointAccessRestricted(meta) {
+		return nil, nil
+	}
+	objectMeta := metav1.ObjectMeta{
+		Name:      endpointAccessName(meta),
+		Namespace: meta.Namespace,
+		Labels: map[string]string{
+			config.WorkspaceIDLabel: meta.WorkspaceId,
+		},
+	}
+	role := rbacv1.Role{
+		ObjectMeta: objectMeta,
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{v1alpha1.SchemeGroupVersion.Group},
+				Resources:     []string{"workspaceroutings"},
+				ResourceNames: []string{meta.RoutingName},
+				Verbs:         []string{endpointAccessVerb},
+			},
+		},
+	}
+	var subjects []rbacv1.Subject
+	for _, user := range meta.AllowedUsers {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:     rbacv1.UserKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     user,
+		})
+	}
+	for _, group := range meta.AllowedGroups {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:     rbacv1.GroupKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     group,
+		})
+	}
+	roleBinding := rbacv1.RoleBinding{
+		ObjectMeta: *objectMeta.DeepCopy(),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: subjects,
+	}
+	return []rbacv1.Role{role}, []rbacv1.RoleBinding{roleBinding}
+}
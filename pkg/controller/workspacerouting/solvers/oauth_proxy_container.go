@@ -50,6 +50,24 @@ func buildSecretVolume(secretName string) corev1.Volume {
 func getProxyContainerForEndpoint(proxyEndpoint proxyEndpoint, tlsProxyVolume corev1.Volume, meta WorkspaceMetadata) corev1.Container {
 	proxyContainerName := fmt.Sprintf("oauth-proxy-%s", strconv.FormatInt(proxyEndpoint.upstreamEndpoint.Port, 10))
 
+	args := []string{
+		"--https-address=:" + strconv.FormatInt(proxyEndpoint.publicEndpoint.Port, 10),
+		"--http-address=127.0.0.1:" + strconv.FormatInt(proxyEndpoint.publicEndpointHttpPort, 10),
+		"--provider=openshift",
+		"--upstream=http://localhost:" + strconv.FormatInt(proxyEndpoint.upstreamEndpoint.Port, 10),
+		"--tls-cert=/etc/tls/private/tls.crt",
+		"--tls-key=/etc/tls/private/tls.key",
+		"--cookie-secret=0123456789abcdefabcd",
+		"--client-id=" + meta.WorkspaceId + "-oauth-client",
+		"--client-secret=1234567890",
+		"--pass-user-bearer-token=false",
+		"--pass-access-token=true",
+		"--scope=user:full",
+	}
+	if accessReview := getEndpointAccessReview(meta); accessReview != "" {
+		args = append(args, "--openshift-sar="+accessReview)
+	}
+
 	return corev1.Container{
 		Name: proxyContainerName,
 		Ports: []corev1.ContainerPort{
@@ -68,19 +86,6 @@ func getProxyContainerForEndpoint(proxyEndpoint proxyEndpoint, tlsProxyVolume co
 		},
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		Image:                    "openshift/oauth-proxy:latest",
-		Args: []string{
-			"--https-address=:" + strconv.FormatInt(proxyEndpoint.publicEndpoint.Port, 10),
-			"--http-address=127.0.0.1:" + strconv.FormatInt(proxyEndpoint.publicEndpointHttpPort, 10),
-			"--provider=openshift",
-			"--upstream=http://localhost:" + strconv.FormatInt(proxyEndpoint.upstreamEndpoint.Port, 10),
-			"--tls-cert=/etc/tls/private/tls.crt",
-			"--tls-key=/etc/tls/private/tls.key",
-			"--cookie-secret=0123456789abcdefabcd",
-			"--client-id=" + meta.WorkspaceId + "-oauth-client",
-			"--client-secret=1234567890",
-			"--pass-user-bearer-token=false",
-			"--pass-access-token=true",
-			"--scope=user:full",
-		},
+		Args:                     args,
 	}
 }
@@ -75,12 +75,16 @@ func (s *OpenShiftOAuthSolver) GetSpecObjects(spec v1alpha1.WorkspaceRoutingSpec
 		RedirectURIs: publicURls,
 	}
 
+	roles, roleBindings := getEndpointAccessRBAC(workspaceMeta)
+
 	return RoutingObjects{
 		Services:     services,
 		Ingresses:    defaultIngresses,
 		Routes:       append(routes, defaultRoutes...),
 		PodAdditions: podAdditions,
 		OAuthClient:  oauthClient,
+		Roles:        roles,
+		RoleBindings: roleBindings,
 	}
 }
 
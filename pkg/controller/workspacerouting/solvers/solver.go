@@ -18,6 +18,7 @@ import (
 	routeV1 "github.com/openshift/api/route/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 type RoutingObjects struct {
@@ -26,6 +27,8 @@ type RoutingObjects struct {
 	Routes       []routeV1.Route
 	PodAdditions *v1alpha1.PodAdditions
 	OAuthClient  *oauthv1.OAuthClient
+	Roles        []rbacv1.Role
+	RoleBindings []rbacv1.RoleBinding
 }
 
 type RoutingSolver interface {
@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspacerouting
+
+import (
+	"context"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var roleDiffOpts = cmp.Options{
+	cmpopts.IgnoreFields(rbacv1.Role{}, "TypeMeta", "ObjectMeta"),
+	cmpopts.EquateEmpty(),
+}
+
+var roleBindingDiffOpts = cmp.Options{
+	cmpopts.IgnoreFields(rbacv1.RoleBinding{}, "TypeMeta", "ObjectMeta"),
+	cmpopts.EquateEmpty(),
+}
+
+// syncRoles synchronizes the Roles granting access to the workspace's endpoints
+func (r *ReconcileWorkspaceRouting) syncRoles(routing *v1alpha1.WorkspaceRouting, specRoles []rbacv1.Role) (ok bool, err error) {
+	rolesInSync := true
+
+	found := &rbacv1.RoleList{}
+	err = r.client.List(context.TODO(), found, client.InNamespace(routing.Namespace), client.MatchingLabels{config.WorkspaceIDLabel: routing.Spec.WorkspaceId})
+	if err != nil {
+		return false, err
+	}
+	clusterRoles := found.Items
+
+	for _, clusterRole := range clusterRoles {
+		if !containsRole(clusterRole.Name, specRoles) {
+			err := r.client.Delete(context.TODO(), &clusterRole)
+			if err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			rolesInSync = false
+		}
+	}
+
+	for _, specRole := range specRoles {
+		if idx := indexOfRole(specRole.Name, clusterRoles); idx >= 0 {
+			clusterRole := clusterRoles[idx]
+			if !cmp.Equal(specRole, clusterRole, roleDiffOpts) {
+				clusterRole.Rules = specRole.Rules
+				err := r.client.Update(context.TODO(), &clusterRole)
+				if err != nil && !errors.IsConflict(err) {
+					return false, err
+				}
+				rolesInSync = false
+			}
+		} else {
+			err := r.client.Create(context.TODO(), &specRole)
+			if err != nil {
+				return false, err
+			}
+			rolesInSync = false
+		}
+	}
+
+	return rolesInSync, nil
+}
+
+// syncRoleBindings synchronizes the RoleBindings granting access to the workspace's endpoints to the users and groups
+// of the routing
+func (r *ReconcileWorkspaceRouting) syncRoleBindings(routing *v1alpha1.WorkspaceRouting, specRoleBindings []rbacv1.RoleBinding) (ok bool, err error) {
+	roleBindingsInSync := true
+
+	found := &rbacv1.RoleBindingList{}
+	err = r.client.List(context.TODO(), found, client.InNamespace(routing.Namespace), client.MatchingLabels{config.WorkspaceIDLabel: routing.Spec.WorkspaceId})
+	if err != nil {
+		return false, err
+	}
+	clusterRoleBindings := found.Items
+
+	for _, clusterRoleBinding := range clusterRoleBindings {
+		idx := indexOfRoleBinding(clusterRoleBinding.Name, specRoleBindings)
+		// The role referenced by a RoleBinding is immutable; RoleBindings referencing another role are recreated
+		if idx < 0 || clusterRoleBinding.RoleRef != specRoleBindings[idx].RoleRef {
+			err := r.client.Delete(context.TODO(), &clusterRoleBinding)
+			if err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			roleBindingsInSync = false
+		}
+	}
+	if !roleBindingsInSync {
+		return false, nil
+	}
+
+	for _, specRoleBinding := range specRoleBindings {
+		if idx := indexOfRoleBinding(specRoleBinding.Name, clusterRoleBindings); idx >= 0 {
+			clusterRoleBinding := clusterRoleBindings[idx]
+			if !cmp.Equal(specRoleBinding, clusterRoleBinding, roleBindingDiffOpts) {
+				clusterRoleBinding.Subjects = specRoleBinding.Subjects
+				err := r.client.Update(context.TODO(), &clusterRoleBinding)
+				if err != nil && !errors.IsConflict(err) {
+					return false, err
+				}
+				roleBindingsInSync = false
+			}
+		} else {
+			err := r.client.Create(context.TODO(), &specRoleBinding)
+			if err != nil {
+				return false, err
+			}
+			roleBindingsInSync = false
+		}
+	}
+
+	return roleBindingsInSync, nil
+}
+
+func containsRole(name string, roles []rbacv1.Role) bool {
+	return indexOfRole(name, roles) >= 0
+}
+
+func indexOfRole(name string, roles []rbacv1.Role) int {
+	for idx, role := range roles {
+		if role.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+func indexOfRoleBinding(name string, roleBindings []rbacv1.RoleBinding) int {
+	for idx, roleBinding := range roleBindings {
+		if roleBinding.Name == name {
+			return idx
+		}
+	}
+	return -1
+}
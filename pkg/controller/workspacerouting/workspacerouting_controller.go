@@ -25,6 +25,7 @@ import (
 	routeV1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -66,7 +67,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	// Watch for changes to secondary resources: Services, Ingresses, and (on OpenShift) Routes.
+	// Watch for changes to secondary resources: Services, Ingresses, Roles, RoleBindings, and (on OpenShift) Routes.
 	err = c.Watch(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
 		IsController: true,
 		OwnerType:    &workspacev1alpha1.WorkspaceRouting{},
@@ -82,6 +83,21 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	err = c.Watch(&source.Kind{Type: &rbacv1.Role{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &workspacev1alpha1.WorkspaceRouting{},
+	})
+	if err != nil {
+		return err
+	}
+	err = c.Watch(&source.Kind{Type: &rbacv1.RoleBinding{}}, &handler.EnqueueRequestForOwner{
+		IsController: true,
+		OwnerType:    &workspacev1alpha1.WorkspaceRouting{},
+	})
+	if err != nil {
+		return err
+	}
+
 	isOpenShift, err := cluster.IsOpenShift()
 	if err != nil {
 		log.Error(err, "Failed to determine if running in OpenShift")
@@ -148,6 +164,9 @@ func (r *ReconcileWorkspaceRouting) Reconcile(request reconcile.Request) (reconc
 		Namespace:     instance.Namespace,
 		PodSelector:   instance.Spec.PodSelector,
 		RoutingSuffix: instance.Spec.RoutingSuffix,
+		RoutingName:   instance.Name,
+		AllowedUsers:  instance.Spec.AllowedUsers,
+		AllowedGroups: instance.Spec.AllowedGroups,
 	}
 
 	if instance.Status.Phase == workspacev1alpha1.RoutingFailed {
@@ -184,6 +203,20 @@ func (r *ReconcileWorkspaceRouting) Reconcile(request reconcile.Request) (reconc
 			return reconcile.Result{}, err
 		}
 	}
+	roles := routingObjects.Roles
+	for idx := range roles {
+		err := controllerutil.SetControllerReference(instance, &roles[idx], r.scheme)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	roleBindings := routingObjects.RoleBindings
+	for idx := range roleBindings {
+		err := controllerutil.SetControllerReference(instance, &roleBindings[idx], r.scheme)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 
 	servicesInSync, clusterServices, err := r.syncServices(instance, services)
 	if err != nil || !servicesInSync {
@@ -203,6 +236,18 @@ func (r *ReconcileWorkspaceRouting) Reconcile(request reconcile.Request) (reconc
 		return reconcile.Result{Requeue: true}, err
 	}
 
+	rolesInSync, err := r.syncRoles(instance, roles)
+	if err != nil || !rolesInSync {
+		reqLogger.Info("Roles not in sync")
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	roleBindingsInSync, err := r.syncRoleBindings(instance, roleBindings)
+	if err != nil || !roleBindingsInSync {
+		reqLogger.Info("RoleBindings not in sync")
+		return reconcile.Result{Requeue: true}, err
+	}
+
 	clusterRoutingObj := solvers.RoutingObjects{
 		Services:  clusterServices,
 		Ingresses: clusterIngresses,
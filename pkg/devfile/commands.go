@@ -0,0 +1,61 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package devfile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+)
+
+// validateCommands checks that every command of a devfile has either actions or commands, and that composite
+// commands only refer to defined commands without cycles.
+func validateCommands(devfile *v1alpha1.DevfileSpec) error {
+	commands := map[string]v1alpha1.CommandSpec{}
+	for _, command := range devfile.Commands {
+		if _, ok := commands[command.Name]; ok {
+			return fmt.Errorf("duplicate command name: %s", command.Name)
+		}
+		commands[command.Name] = command
+	}
+	for _, command := range devfile.Commands {
+		if (len(command.Actions) == 0) == (len(command.Commands) == 0) {
+			return fmt.Errorf("command %s must have either actions or commands", command.Name)
+		}
+		if err := checkCompositeCommand(command, commands, []string{command.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCompositeCommand recursively checks the commands referred to by a composite command. Visited contains the names
+// of the composite commands that are being checked, to detect cycles.
+func checkCompositeCommand(command v1alpha1.CommandSpec, commands map[string]v1alpha1.CommandSpec, visited []string) error {
+	for _, name := range command.Commands {
+		subCommand, ok := commands[name]
+		if !ok {
+			return fmt.Errorf("composite command %s refers to undefined command %s", command.Name, name)
+		}
+		for _, visitedName := range visited {
+			if visitedName == name {
+				return fmt.Errorf("cyclic reference in composite commands: %s -> %s", strings.Join(visited, " -> "), name)
+			}
+		}
+		if err := checkCompositeCommand(subCommand, commands, append(visited[:len(visited):len(visited)], name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
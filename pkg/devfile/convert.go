@@ -0,0 +1,315 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package devfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+)
+
+const (
+	execActionType  = "exec"
+	applyActionType = "apply"
+)
+
+// ConvertDevfileV2 converts a devfile 2.0 into the internal representation:
+//  - container components are converted into dockerimage components, volume components into the volumes of the
+//    containers mounting them
+//  - plugin components are converted into chePlugin components, or cheEditor components for Che-Theia
+//  - exec and apply commands are converted into commands with a single action; composite commands into commands
+//    that refer to their commands by name, and whether these run in parallel
+// Devfiles with a parent must be merged into their template before conversion (see ResolveWorkspaceDevfile).
+func ConvertDevfileV2(devfile *v1alpha1.DevfileV2Spec) (*v1alpha1.DevfileSpec, error) {
+	if devfile.Parent != nil {
+		return nil, fmt.Errorf("devfile parent must be resolved before conversion")
+	}
+	components, err := convertComponents(devfile.Components)
+	if err != nil {
+		return nil, err
+	}
+	commands, err := convertCommands(devfile.Commands)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := convertProjects(devfile.Projects)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.DevfileSpec{
+		APIVersion:  "1.0.0",
+		DevfileMeta: devfile.Metadata,
+		Projects:    projects,
+		Components:  components,
+		Commands:    commands,
+		Events:      devfile.Events.DeepCopy(),
+	}, nil
+}
+
+func convertComponents(v2Components []v1alpha1.DevfileV2Component) ([]v1alpha1.ComponentSpec, error) {
+	volumes := map[string]bool{}
+	names := map[string]bool{}
+	for _, v2Component := range v2Components {
+		if v2Component.Name == "" {
+			return nil, fmt.Errorf("devfile component name must not be empty")
+		}
+		if names[v2Component.Name] {
+			return nil, fmt.Errorf("duplicate devfile component name: %s", v2Component.Name)
+		}
+		names[v2Component.Name] = true
+		if v2Component.Volume != nil {
+			volumes[v2Component.Name] = true
+		}
+	}
+
+	var components []v1alpha1.ComponentSpec
+	for _, v2Component := range v2Components {
+		if err := checkOneOf(v2Component.Container != nil, v2Component.Volume != nil, v2Component.Kubernetes != nil,
+			v2Component.Openshift != nil, v2Component.Plugin != nil); err != nil {
+			return nil, fmt.Errorf("invalid devfile component %s: %s", v2Component.Name, err)
+		}
+		switch {
+		case v2Component.Container != nil:
+			component, err := convertContainerComponent(v2Component.Name, v2Component.Container, volumes)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, component)
+		case v2Component.Plugin != nil:
+			components = append(components, convertPluginComponent(v2Component.Name, v2Component.Plugin))
+		case v2Component.Kubernetes != nil:
+			components = append(components, convertKubernetesComponent(v2Component.Name, v1alpha1.Kubernetes, v2Component.Kubernetes))
+		case v2Component.Openshift != nil:
+			components = append(components, convertKubernetesComponent(v2Component.Name, v1alpha1.Openshift, v2Component.Openshift))
+		case v2Component.Volume != nil:
+			// Volumes are converted as part of the containers that mount them
+		}
+	}
+	return components, nil
+}
+
+func convertContainerComponent(name string, container *v1alpha1.DevfileV2ContainerComponent, volumes map[string]bool) (v1alpha1.ComponentSpec, error) {
+	mountSources := true
+	if container.MountSources != nil {
+		mountSources = *container.MountSources
+	}
+
+	var componentVolumes []v1alpha1.Volume
+	for _, volumeMount := range container.VolumeMounts {
+		if !volumes[volumeMount.Name] {
+			return v1alpha1.ComponentSpec{}, fmt.Errorf("container component %s mounts volume %s, which is not a volume component", name, volumeMount.Name)
+		}
+		componentVolumes = append(componentVolumes, v1alpha1.Volume{
+			Name:          volumeMount.Name,
+			ContainerPath: volumeMount.Path,
+		})
+	}
+
+	var endpoints []v1alpha1.Endpoint
+	for _, v2Endpoint := range container.Endpoints {
+		endpoints = append(endpoints, convertEndpoint(v2Endpoint))
+	}
+
+	return v1alpha1.ComponentSpec{
+		Type:          v1alpha1.Dockerimage,
+		Alias:         name,
+		Image:         container.Image,
+		MemoryLimit:   container.MemoryLimit,
+		MemoryRequest: container.MemoryRequest,
+		CpuLimit:      container.CpuLimit,
+		CpuRequest:    container.CpuRequest,
+		MountSources:  mountSources,
+		Endpoints:     endpoints,
+		Env:           container.Env,
+		Volumes:       componentVolumes,
+		Command:       container.Command,
+		Args:          container.Args,
+	}, nil
+}
+
+func convertEndpoint(v2Endpoint v1alpha1.DevfileV2Endpoint) v1alpha1.Endpoint {
+	attributes := map[v1alpha1.EndpointAttribute]string{}
+	for key, value := range v2Endpoint.Attributes {
+		attributes[v1alpha1.EndpointAttribute(key)] = value
+	}
+	switch v2Endpoint.Exposure {
+	case v1alpha1.InternalEndpointExposure:
+		attributes[v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE] = "false"
+		attributes[v1alpha1.DISCOVERABLE_ATTRIBUTE] = "true"
+	case v1alpha1.NoneEndpointExposure:
+		attributes[v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE] = "false"
+	default:
+		attributes[v1alpha1.PUBLIC_ENDPOINT_ATTRIBUTE] = "true"
+	}
+	if v2Endpoint.Secure {
+		attributes[v1alpha1.SECURE_ENDPOINT_ATTRIBUTE] = "true"
+	}
+	if v2Endpoint.Protocol != "" {
+		attributes[v1alpha1.PROTOCOL_ENDPOINT_ATTRIBUTE] = v2Endpoint.Protocol
+	}
+	if v2Endpoint.Path != "" {
+		attributes[v1alpha1.PATH_ENDPOINT_ATTRIBUTE] = v2Endpoint.Path
+	}
+	return v1alpha1.Endpoint{
+		Name:       v2Endpoint.Name,
+		Port:       v2Endpoint.TargetPort,
+		Attributes: attributes,
+	}
+}
+
+func convertPluginComponent(name string, plugin *v1alpha1.DevfileV2PluginComponent) v1alpha1.ComponentSpec {
+	id := plugin.Id
+	if plugin.RegistryUrl != "" {
+		id = plugin.RegistryUrl + "#" + plugin.Id
+	}
+	// Devfile 2.0 does not distinguish editors from plugins; Che-Theia is the only editor supported by the controller
+	componentType := v1alpha1.ChePlugin
+	if strings.HasPrefix(plugin.Id, config.TheiaEditorID) {
+		componentType = v1alpha1.CheEditor
+	}
+	return v1alpha1.ComponentSpec{
+		Type:        componentType,
+		Alias:       name,
+		Id:          id,
+		MemoryLimit: plugin.MemoryLimit,
+	}
+}
+
+func convertKubernetesComponent(name string, componentType v1alpha1.ComponentType, kubernetes *v1alpha1.DevfileV2KubernetesComponent) v1alpha1.ComponentSpec {
+	component := v1alpha1.ComponentSpec{
+		Type:      componentType,
+		Alias:     name,
+		Reference: kubernetes.Uri,
+	}
+	if kubernetes.Inlined != "" {
+		inlined := kubernetes.Inlined
+		component.ReferenceContent = &inlined
+	}
+	return component
+}
+
+func convertCommands(v2Commands []v1alpha1.DevfileV2Command) ([]v1alpha1.CommandSpec, error) {
+	commandsByID := map[string]v1alpha1.DevfileV2Command{}
+	for _, v2Command := range v2Commands {
+		if v2Command.Id == "" {
+			return nil, fmt.Errorf("devfile command id must not be empty")
+		}
+		if _, ok := commandsByID[v2Command.Id]; ok {
+			return nil, fmt.Errorf("duplicate devfile command id: %s", v2Command.Id)
+		}
+		if err := checkOneOf(v2Command.Exec != nil, v2Command.Apply != nil, v2Command.Composite != nil); err != nil {
+			return nil, fmt.Errorf("invalid devfile command %s: %s", v2Command.Id, err)
+		}
+		commandsByID[v2Command.Id] = v2Command
+	}
+
+	var commands []v1alpha1.CommandSpec
+	for _, v2Command := range v2Commands {
+		label, group := getCommandLabelAndGroup(v2Command)
+		attributes := map[string]string{}
+		if label != "" {
+			attributes[config.CommandLabelAttribute] = label
+		}
+		if group != nil {
+			attributes[config.CommandGroupAttribute] = group.Kind
+			attributes[config.CommandIsDefaultAttribute] = strconv.FormatBool(group.IsDefault)
+		}
+		if len(attributes) == 0 {
+			attributes = nil
+		}
+		command := v1alpha1.CommandSpec{
+			Name:       v2Command.Id,
+			Attributes: attributes,
+		}
+		switch {
+		case v2Command.Exec != nil:
+			command.Actions = []v1alpha1.CommandActionSpec{
+				{
+					Type:      execActionType,
+					Component: v2Command.Exec.Component,
+					Command:   v2Command.Exec.CommandLine,
+					Workdir:   v2Command.Exec.WorkingDir,
+				},
+			}
+		case v2Command.Apply != nil:
+			command.Actions = []v1alpha1.CommandActionSpec{
+				{
+					Type:      applyActionType,
+					Component: v2Command.Apply.Component,
+				},
+			}
+		default:
+			command.Commands = v2Command.Composite.Commands
+			command.Parallel = v2Command.Composite.Parallel
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+func getCommandLabelAndGroup(v2Command v1alpha1.DevfileV2Command) (string, *v1alpha1.DevfileV2CommandGroup) {
+	switch {
+	case v2Command.Exec != nil:
+		return v2Command.Exec.Label, v2Command.Exec.Group
+	case v2Command.Apply != nil:
+		return v2Command.Apply.Label, v2Command.Apply.Group
+	default:
+		return v2Command.Composite.Label, v2Command.Composite.Group
+	}
+}
+
+func convertProjects(v2Projects []v1alpha1.DevfileV2Project) ([]v1alpha1.ProjectSpec, error) {
+	var projects []v1alpha1.ProjectSpec
+	for _, v2Project := range v2Projects {
+		if err := checkOneOf(v2Project.Git != nil, v2Project.Github != nil, v2Project.Zip != nil); err != nil {
+			return nil, fmt.Errorf("invalid devfile project %s: %s", v2Project.Name, err)
+		}
+		var sourceType string
+		var source *v1alpha1.DevfileV2ProjectSource
+		switch {
+		case v2Project.Git != nil:
+			sourceType, source = "git", v2Project.Git
+		case v2Project.Github != nil:
+			sourceType, source = "github", v2Project.Github
+		default:
+			sourceType, source = "zip", v2Project.Zip
+		}
+		projects = append(projects, v1alpha1.ProjectSpec{
+			Name:      v2Project.Name,
+			ClonePath: v2Project.ClonePath,
+			Source: v1alpha1.ProjectSourceSpec{
+				Type:     sourceType,
+				Location: source.Location,
+				Branch:   source.Branch,
+			},
+		})
+	}
+	return projects, nil
+}
+
+// checkOneOf returns an error unless exactly one of the fields of a union is set
+func checkOneOf(isSet ...bool) error {
+	count := 0
+	for _, set := range isSet {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("exactly one type must be specified, found %d", count)
+	}
+	return nil
+}
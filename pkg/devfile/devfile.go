@@ -0,0 +1,40 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+// Package devfile resolves the devfile of a workspace into the internal representation used by the controllers.
+//
+// The internal representation is the devfile 1.0 model (v1alpha1.DevfileSpec); devfiles specified in the 2.0 format
+// are converted into it, so that the provision and adaptor packages only have to deal with one model.
+package devfile
+
+import (
+	"errors"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+)
+
+// GetWorkspaceDevfile returns the devfile of a workspace that does not use a template in the internal representation.
+// Exactly one of the workspace's devfile and devfileV2 fields must be set. The returned devfile must not be modified,
+// as it may be shared with the workspace object.
+func GetWorkspaceDevfile(workspace *v1alpha1.Workspace) (*v1alpha1.DevfileSpec, error) {
+	devfile, devfileV2 := workspace.Spec.Devfile, workspace.Spec.DevfileV2
+	switch {
+	case devfile != nil && devfileV2 != nil:
+		return nil, errors.New("only one of devfile and devfileV2 may be specified")
+	case devfile != nil:
+		return devfile, nil
+	case devfileV2 != nil:
+		return ConvertDevfileV2(devfileV2)
+	default:
+		return nil, errors.New("one of devfile and devfileV2 must be specified")
+	}
+}
@@ -0,0 +1,73 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package devfile
+
+import (
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+)
+
+// validateEvents checks that the commands bound to the postStart and preStop events of a devfile exist and only
+// consist of exec actions targeting dockerimage components, either directly or through composite commands, as they are
+// run by lifecycle hooks of the components' containers.
+func validateEvents(devfile *v1alpha1.DevfileSpec) error {
+	if devfile.Events == nil {
+		return nil
+	}
+	dockerimageComponents := map[string]bool{}
+	for _, component := range devfile.Components {
+		if component.Type == v1alpha1.Dockerimage {
+			dockerimageComponents[component.Alias] = true
+		}
+	}
+	commands := map[string]v1alpha1.CommandSpec{}
+	for _, command := range devfile.Commands {
+		commands[command.Name] = command
+	}
+
+	var validateCommand func(event, name string) error
+	validateCommand = func(event, name string) error {
+		command, ok := commands[name]
+		if !ok {
+			return fmt.Errorf("%s event refers to undefined command %s", event, name)
+		}
+		for _, action := range command.Actions {
+			if action.Type != execActionType {
+				return fmt.Errorf("command %s is bound to %s event, but has an action of type %s; only exec actions are supported", name, event, action.Type)
+			}
+			if !dockerimageComponents[action.Component] {
+				return fmt.Errorf("command %s is bound to %s event, but its action targets %s, which is not a dockerimage component", name, event, action.Component)
+			}
+		}
+		// Composite commands are checked for cycles by validateCommands
+		for _, subCommand := range command.Commands {
+			if err := validateCommand(event, subCommand); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	validate := func(event string, commandNames []string) error {
+		for _, name := range commandNames {
+			if err := validateCommand(event, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := validate("postStart", devfile.Events.PostStart); err != nil {
+		return err
+	}
+	return validate("preStop", devfile.Events.PreStop)
+}
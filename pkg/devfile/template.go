@@ -0,0 +1,291 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package devfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveWorkspaceDevfile returns the devfile of a workspace in the internal representation. If the workspace uses a
+// WorkspaceTemplate, either through spec.template or as the parent of its devfile 2.0, the workspace's devfile is
+// merged into the template's:
+//  - components, commands and projects override those of the template with the same key (component alias or name,
+//    command name or id and project name) and are appended to the template's otherwise. A cheEditor component
+//    replaces the template's editor regardless of its alias.
+//  - events override the template's events of the same kind
+//  - devfile 1.0 attributes are enabled if they are enabled in either devfile
+// The commands of the resolved devfile, including composite commands and those bound to events, are validated. Errors
+// returned by the API server while getting the template are returned as is, so that callers can tell them apart from
+// invalid devfiles.
+func ResolveWorkspaceDevfile(c client.Client, workspace *v1alpha1.Workspace) (*v1alpha1.DevfileSpec, error) {
+	devfile, err := resolveWorkspaceDevfile(c, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCommands(devfile); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(devfile); err != nil {
+		return nil, err
+	}
+	return devfile, nil
+}
+
+func resolveWorkspaceDevfile(c client.Client, workspace *v1alpha1.Workspace) (*v1alpha1.DevfileSpec, error) {
+	templateRef, err := GetTemplateReference(workspace)
+	if err != nil {
+		return nil, err
+	}
+	if templateRef == nil {
+		return GetWorkspaceDevfile(workspace)
+	}
+	if templateRef.Namespace != workspace.Namespace && config.ControllerCfg.GetWebhooksEnabled() != "true" {
+		// Access to templates in other namespaces is checked by the webhook server
+		return nil, fmt.Errorf("template %s/%s is in a different namespace, which requires webhooks to be enabled", templateRef.Namespace, templateRef.Name)
+	}
+
+	template := &v1alpha1.WorkspaceTemplate{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: templateRef.Name, Namespace: templateRef.Namespace}, template)
+	if err != nil {
+		return nil, err
+	}
+
+	templateDevfile, templateDevfileV2 := template.Spec.Devfile, template.Spec.DevfileV2
+	switch {
+	case templateDevfile != nil && templateDevfileV2 != nil:
+		return nil, fmt.Errorf("template %s/%s: only one of devfile and devfileV2 may be specified", templateRef.Namespace, templateRef.Name)
+	case templateDevfile != nil:
+		if workspace.Spec.DevfileV2 != nil {
+			return nil, fmt.Errorf("template %s/%s uses devfile 1.0, so the workspace must not use devfileV2", templateRef.Namespace, templateRef.Name)
+		}
+		return mergeDevfile(templateDevfile, workspace.Spec.Devfile), nil
+	case templateDevfileV2 != nil:
+		if workspace.Spec.Devfile != nil {
+			return nil, fmt.Errorf("template %s/%s uses devfile 2.0, so the workspace must not use devfile", templateRef.Namespace, templateRef.Name)
+		}
+		if templateDevfileV2.Parent != nil {
+			return nil, fmt.Errorf("template %s/%s: templates must not have a parent", templateRef.Namespace, templateRef.Name)
+		}
+		return ConvertDevfileV2(mergeDevfileV2(templateDevfileV2, workspace.Spec.DevfileV2))
+	default:
+		return nil, fmt.Errorf("template %s/%s: one of devfile and devfileV2 must be specified", templateRef.Namespace, templateRef.Name)
+	}
+}
+
+// GetTemplateReference returns the reference to the WorkspaceTemplate used by a workspace, with the namespace
+// defaulted to the workspace's namespace, or nil if the workspace does not use a template.
+func GetTemplateReference(workspace *v1alpha1.Workspace) (*v1alpha1.KubernetesReference, error) {
+	templateRef := workspace.Spec.Template
+	if devfileV2 := workspace.Spec.DevfileV2; devfileV2 != nil && devfileV2.Parent != nil {
+		parent := devfileV2.Parent
+		if parent.Uri != "" || parent.Id != "" || parent.Kubernetes == nil {
+			return nil, errors.New("devfile parents are only supported as references to a WorkspaceTemplate")
+		}
+		if templateRef != nil {
+			return nil, errors.New("only one of template and the devfile parent may be specified")
+		}
+		templateRef = parent.Kubernetes
+	}
+	if templateRef == nil {
+		return nil, nil
+	}
+	if templateRef.Name == "" {
+		return nil, errors.New("template name must not be empty")
+	}
+	resolved := templateRef.DeepCopy()
+	if resolved.Namespace == "" {
+		resolved.Namespace = workspace.Namespace
+	}
+	return resolved, nil
+}
+
+func mergeDevfile(template, overrides *v1alpha1.DevfileSpec) *v1alpha1.DevfileSpec {
+	merged := template.DeepCopy()
+	if overrides == nil {
+		return merged
+	}
+	if overrides.APIVersion != "" {
+		merged.APIVersion = overrides.APIVersion
+	}
+	if overrides.Name != "" || overrides.GenerateName != "" {
+		merged.DevfileMeta = overrides.DevfileMeta
+	}
+	merged.PersistVolumes = merged.PersistVolumes || overrides.PersistVolumes
+	merged.EditorFree = merged.EditorFree || overrides.EditorFree
+	merged.Events = mergeEvents(merged.Events, overrides.Events)
+
+	mergeByKey(len(merged.Components), len(overrides.Components),
+		func(idx int) string { return componentKey(merged.Components[idx]) },
+		func(idx int) string { return componentKey(overrides.Components[idx]) },
+		func(idx, overrideIdx int) {
+			component := *overrides.Components[overrideIdx].DeepCopy()
+			if idx < 0 {
+				merged.Components = append(merged.Components, component)
+			} else {
+				merged.Components[idx] = component
+			}
+		})
+	mergeByKey(len(merged.Commands), len(overrides.Commands),
+		func(idx int) string { return merged.Commands[idx].Name },
+		func(idx int) string { return overrides.Commands[idx].Name },
+		func(idx, overrideIdx int) {
+			command := *overrides.Commands[overrideIdx].DeepCopy()
+			if idx < 0 {
+				merged.Commands = append(merged.Commands, command)
+			} else {
+				merged.Commands[idx] = command
+			}
+		})
+	mergeByKey(len(merged.Projects), len(overrides.Projects),
+		func(idx int) string { return merged.Projects[idx].Name },
+		func(idx int) string { return overrides.Projects[idx].Name },
+		func(idx, overrideIdx int) {
+			project := *overrides.Projects[overrideIdx].DeepCopy()
+			if idx < 0 {
+				merged.Projects = append(merged.Projects, project)
+			} else {
+				merged.Projects[idx] = project
+			}
+		})
+	return merged
+}
+
+// componentKey returns the key used to match devfile 1.0 components when merging devfiles. Components without an
+// alias are matched by id; other components without an alias are never matched.
+func componentKey(component v1alpha1.ComponentSpec) string {
+	switch {
+	case component.Type == v1alpha1.CheEditor:
+		// A workspace has a single editor
+		return string(v1alpha1.CheEditor)
+	case component.Alias != "":
+		return component.Alias
+	default:
+		return component.Id
+	}
+}
+
+// mergeDevfileV2 merges a devfile 2.0 into its template. The overrides specified in the devfile's parent are applied
+// before the devfile's own components, commands and projects. The returned devfile has no parent.
+func mergeDevfileV2(template, devfile *v1alpha1.DevfileV2Spec) *v1alpha1.DevfileV2Spec {
+	merged := template.DeepCopy()
+	if devfile == nil {
+		return merged
+	}
+	if devfile.SchemaVersion != "" {
+		merged.SchemaVersion = devfile.SchemaVersion
+	}
+	if devfile.Metadata.Name != "" || devfile.Metadata.GenerateName != "" {
+		merged.Metadata = devfile.Metadata
+	}
+	merged.Events = mergeEvents(merged.Events, devfile.Events)
+
+	overrides := []v1alpha1.DevfileV2Spec{{}, *devfile}
+	if devfile.Parent != nil {
+		overrides[0] = v1alpha1.DevfileV2Spec{
+			Components: devfile.Parent.Components,
+			Commands:   devfile.Parent.Commands,
+			Projects:   devfile.Parent.Projects,
+		}
+	}
+	for _, override := range overrides {
+		mergeByKey(len(merged.Components), len(override.Components),
+			func(idx int) string { return merged.Components[idx].Name },
+			func(idx int) string { return override.Components[idx].Name },
+			func(idx, overrideIdx int) {
+				component := *override.Components[overrideIdx].DeepCopy()
+				if idx < 0 {
+					merged.Components = append(merged.Components, component)
+				} else {
+					merged.Components[idx] = component
+				}
+			})
+		mergeByKey(len(merged.Commands), len(override.Commands),
+			func(idx int) string { return merged.Commands[idx].Id },
+			func(idx int) string { return override.Commands[idx].Id },
+			func(idx, overrideIdx int) {
+				command := *override.Commands[overrideIdx].DeepCopy()
+				if idx < 0 {
+					merged.Commands = append(merged.Commands, command)
+				} else {
+					merged.Commands[idx] = command
+				}
+			})
+		mergeByKey(len(merged.Projects), len(override.Projects),
+			func(idx int) string { return merged.Projects[idx].Name },
+			func(idx int) string { return override.Projects[idx].Name },
+			func(idx, overrideIdx int) {
+				project := *override.Projects[overrideIdx].DeepCopy()
+				if idx < 0 {
+					merged.Projects = append(merged.Projects, project)
+				} else {
+					merged.Projects[idx] = project
+				}
+			})
+	}
+	return merged
+}
+
+// mergeByKey merges a list of overrides into a list of devfile elements, e.g. components, by key: each override
+// replaces the element with the same key, or is appended to the list if there is none. Elements with an empty key are
+// never replaced. keyOf and overrideKeyOf return the keys of the elements of the list and of the overrides; set
+// replaces the element at idx with the override at overrideIdx, or appends the override if idx is -1.
+func mergeByKey(count, overrideCount int, keyOf, overrideKeyOf func(idx int) string, set func(idx, overrideIdx int)) {
+	var keys []string
+	for idx := 0; idx < count; idx++ {
+		keys = append(keys, keyOf(idx))
+	}
+	for overrideIdx := 0; overrideIdx < overrideCount; overrideIdx++ {
+		overrideKey := overrideKeyOf(overrideIdx)
+		idx := -1
+		for i := range keys {
+			if overrideKey != "" && keys[i] == overrideKey {
+				idx = i
+				break
+			}
+		}
+		set(idx, overrideIdx)
+		if idx < 0 {
+			keys = append(keys, overrideKey)
+		}
+	}
+}
+
+func mergeEvents(template, overrides *v1alpha1.DevfileEvents) *v1alpha1.DevfileEvents {
+	if overrides == nil {
+		return template
+	}
+	merged := &v1alpha1.DevfileEvents{}
+	if template != nil {
+		template.DeepCopyInto(merged)
+	}
+	if len(overrides.PreStart) > 0 {
+		merged.PreStart = overrides.PreStart
+	}
+	if len(overrides.PostStart) > 0 {
+		merged.PostStart = overrides.PostStart
+	}
+	if len(overrides.PreStop) > 0 {
+		merged.PreStop = overrides.PreStop
+	}
+	if len(overrides.PostStop) > 0 {
+		merged.PostStop = overrides.PostStop
+	}
+	return merged
+}
@@ -0,0 +1,186 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package devfile
+
+import (
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var supportedComponentTypes = []string{
+	string(v1alpha1.Dockerimage),
+	string(v1alpha1.CheEditor),
+	string(v1alpha1.ChePlugin),
+}
+
+// ValidateWorkspaceDevfile returns the errors in the devfile of a workspace that would make the workspace fail to
+// start, with the path of the offending fields. As the workspace's template is not resolved, only the workspace's own
+// devfile is validated.
+func ValidateWorkspaceDevfile(workspace *v1alpha1.Workspace) field.ErrorList {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+
+	templateRef, err := GetTemplateReference(workspace)
+	if err != nil {
+		templatePath := specPath.Child("template")
+		if workspace.Spec.DevfileV2 != nil && workspace.Spec.DevfileV2.Parent != nil {
+			templatePath = specPath.Child("devfileV2", "parent")
+		}
+		errs = append(errs, field.Invalid(templatePath, templateRefName(workspace), err.Error()))
+	}
+
+	devfile, devfileV2 := workspace.Spec.Devfile, workspace.Spec.DevfileV2
+	switch {
+	case devfile != nil && devfileV2 != nil:
+		errs = append(errs, field.Forbidden(specPath.Child("devfileV2"), "only one of devfile and devfileV2 may be specified"))
+	case devfile == nil && devfileV2 == nil && err == nil && templateRef == nil:
+		errs = append(errs, field.Required(specPath.Child("devfile"), "one of devfile and devfileV2 must be specified"))
+	}
+	if devfile != nil {
+		errs = append(errs, validateDevfile(devfile, specPath.Child("devfile"))...)
+	}
+	if devfileV2 != nil {
+		errs = append(errs, validateDevfileV2(devfileV2, specPath.Child("devfileV2"))...)
+	}
+	return errs
+}
+
+func templateRefName(workspace *v1alpha1.Workspace) string {
+	if workspace.Spec.Template != nil {
+		return workspace.Spec.Template.Name
+	}
+	if parent := workspace.Spec.DevfileV2.Parent; parent.Kubernetes != nil {
+		return parent.Kubernetes.Name
+	}
+	return ""
+}
+
+func validateDevfile(devfile *v1alpha1.DevfileSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	aliases := map[string]bool{}
+	for idx, component := range devfile.Components {
+		componentPath := path.Child("components").Index(idx)
+		switch component.Type {
+		case v1alpha1.Dockerimage:
+			if component.Alias == "" {
+				errs = append(errs, field.Required(componentPath.Child("alias"), "dockerimage components must have an alias"))
+			}
+			if component.Image == "" {
+				errs = append(errs, field.Required(componentPath.Child("image"), "dockerimage components must have an image"))
+			}
+		case v1alpha1.CheEditor, v1alpha1.ChePlugin:
+			if component.Id == "" && component.Reference == "" {
+				errs = append(errs, field.Required(componentPath.Child("id"), fmt.Sprintf("%s components must have an id or a reference", component.Type)))
+			}
+		default:
+			errs = append(errs, field.NotSupported(componentPath.Child("type"), component.Type, supportedComponentTypes))
+		}
+		if component.Alias != "" {
+			if aliases[component.Alias] {
+				errs = append(errs, field.Duplicate(componentPath.Child("alias"), component.Alias))
+			}
+			aliases[component.Alias] = true
+		}
+		errs = append(errs, validateQuantity(component.MemoryLimit, componentPath.Child("memoryLimit"))...)
+		errs = append(errs, validateQuantity(component.MemoryRequest, componentPath.Child("memoryRequest"))...)
+		errs = append(errs, validateQuantity(component.CpuLimit, componentPath.Child("cpuLimit"))...)
+		errs = append(errs, validateQuantity(component.CpuRequest, componentPath.Child("cpuRequest"))...)
+		errs = append(errs, validateEnv(component.Env, componentPath.Child("env"))...)
+	}
+	return errs
+}
+
+func validateDevfileV2(devfile *v1alpha1.DevfileV2Spec, path *field.Path) field.ErrorList {
+	errs := validateDevfileV2Components(devfile.Components, path.Child("components"))
+	if devfile.Parent != nil {
+		errs = append(errs, validateDevfileV2Components(devfile.Parent.Components, path.Child("parent", "components"))...)
+	}
+	return errs
+}
+
+func validateDevfileV2Components(components []v1alpha1.DevfileV2Component, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	names := map[string]bool{}
+	for idx, component := range components {
+		componentPath := path.Index(idx)
+		if component.Name == "" {
+			errs = append(errs, field.Required(componentPath.Child("name"), "component name must not be empty"))
+		} else if names[component.Name] {
+			errs = append(errs, field.Duplicate(componentPath.Child("name"), component.Name))
+		}
+		names[component.Name] = true
+
+		if err := checkOneOf(component.Container != nil, component.Volume != nil, component.Kubernetes != nil,
+			component.Openshift != nil, component.Plugin != nil); err != nil {
+			errs = append(errs, field.Invalid(componentPath, component.Name, err.Error()))
+		}
+		if component.Kubernetes != nil {
+			errs = append(errs, field.Forbidden(componentPath.Child("kubernetes"), "kubernetes components are not supported"))
+		}
+		if component.Openshift != nil {
+			errs = append(errs, field.Forbidden(componentPath.Child("openshift"), "openshift components are not supported"))
+		}
+		if container := component.Container; container != nil {
+			containerPath := componentPath.Child("container")
+			if container.Image == "" {
+				errs = append(errs, field.Required(containerPath.Child("image"), "container components must have an image"))
+			}
+			errs = append(errs, validateQuantity(container.MemoryLimit, containerPath.Child("memoryLimit"))...)
+			errs = append(errs, validateQuantity(container.MemoryRequest, containerPath.Child("memoryRequest"))...)
+			errs = append(errs, validateQuantity(container.CpuLimit, containerPath.Child("cpuLimit"))...)
+			errs = append(errs, validateQuantity(container.CpuRequest, containerPath.Child("cpuRequest"))...)
+			errs = append(errs, validateEnv(container.Env, containerPath.Child("env"))...)
+		}
+		if plugin := component.Plugin; plugin != nil {
+			pluginPath := componentPath.Child("plugin")
+			if plugin.Id == "" {
+				errs = append(errs, field.Required(pluginPath.Child("id"), "plugin components must have an id"))
+			}
+			errs = append(errs, validateQuantity(plugin.MemoryLimit, pluginPath.Child("memoryLimit"))...)
+		}
+		if volume := component.Volume; volume != nil {
+			errs = append(errs, validateQuantity(volume.Size, componentPath.Child("volume", "size"))...)
+		}
+	}
+	return errs
+}
+
+// validateEnv checks that environment variables do not set both a value and a source for the value, as values of
+// variables sourced from Secrets would otherwise be stored in plain text
+func validateEnv(env []v1alpha1.Env, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for idx, envVar := range env {
+		if envVar.Value != "" && envVar.ValueFrom != nil {
+			errs = append(errs, field.Forbidden(path.Index(idx).Child("value"), "may not be specified when valueFrom is specified"))
+		}
+	}
+	return errs
+}
+
+// validateQuantity checks that a resource quantity, if set, can be parsed and is not negative
+func validateQuantity(value string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if quantity.Sign() < 0 {
+		return field.ErrorList{field.Invalid(path, value, "must not be negative")}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/eclipse/che-plugin-broker/model"
 	brokerModel "github.com/eclipse/che-plugin-broker/model"
@@ -65,3 +66,35 @@ func InternalRegistryPluginToMetaYAML(pluginID string) (*brokerModel.PluginMeta,
 	}
 	return &pluginMeta, nil
 }
+
+// GetInternalRegistryImages returns the images of the containers and init containers of the plugins in the internal
+// registry
+func GetInternalRegistryImages() ([]string, error) {
+	var images []string
+	err := filepath.Walk(RegistryDirectory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() != "meta.yaml" {
+			return nil
+		}
+		yamlFile, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var pluginMeta model.PluginMeta
+		if err := yaml.Unmarshal(yamlFile, &pluginMeta); err != nil {
+			return fmt.Errorf("failed to unmarshal meta.yaml '%s': %s", path, err)
+		}
+		for _, container := range append(pluginMeta.Spec.Containers, pluginMeta.Spec.InitContainers...) {
+			if container.Image != "" {
+				images = append(images, container.Image)
+			}
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return images, nil
+}
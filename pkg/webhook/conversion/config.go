@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package conversion
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/che-incubator/che-workspace-operator/internal/controller"
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/che-incubator/che-workspace-operator/pkg/webhook/server"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	workspaceCRDName      = "workspaces.workspace.che.eclipse.org"
+	conversionWebhookPath = "/convert"
+)
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1beta1",
+	Kind:    "CustomResourceDefinition",
+}
+
+//Configure configures the conversion webhook between the versions of the Workspace API and migrates stored workspaces
+//to the storage version set in the controller config
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions;customresourcedefinitions/status,verbs=get;update
+func Configure(_ manager.Manager, ctx context.Context) error {
+	log.Info("Configuring workspace conversion webhook")
+	c, err := controller.CreateClient()
+	if err != nil {
+		return err
+	}
+
+	crd, err := getWorkspaceCRD(ctx, c)
+	if err != nil {
+		return err
+	}
+	storedVersions, _, err := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if err != nil {
+		return err
+	}
+	storageVersion := config.ControllerCfg.GetWorkspaceStorageVersion()
+	hubVersion := v1alpha1.SchemeGroupVersion.Version
+
+	if !server.IsSetUp() {
+		if !isOnlyVersion(storedVersions, hubVersion) {
+			return fmt.Errorf("workspaces are stored in versions %v, which require the conversion webhook, but webhooks server is not set up", storedVersions)
+		}
+		if storageVersion != hubVersion {
+			log.Info(fmt.Sprintf("WARN: Webhooks server is not set up. Workspaces will be stored as %s", hubVersion))
+		}
+		// Without the webhook, versions other than the hub are served as is, so they should be avoided by clients
+		err := updateWorkspaceCRD(ctx, c, crd, map[string]interface{}{"strategy": "None"}, hubVersion)
+		if err != nil {
+			return err
+		}
+		log.Info("Webhooks server is not set up. Workspace conversion webhook is disabled")
+		return nil
+	}
+
+	if err := updateWorkspaceCRD(ctx, c, crd, getWebhookConversion(), storageVersion); err != nil {
+		return err
+	}
+	server.OnCABundleUpdate(func(ctx context.Context) error {
+		crd, err := getWorkspaceCRD(ctx, c)
+		if err != nil {
+			return err
+		}
+		return updateWorkspaceCRD(ctx, c, crd, getWebhookConversion(), storageVersion)
+	})
+
+	conversionWebhook, err := NewWebhook()
+	if err != nil {
+		return err
+	}
+	server.GetWebhookServer().Register(conversionWebhookPath, conversionWebhook)
+
+	if !isOnlyVersion(storedVersions, storageVersion) {
+		// Workspaces can only be migrated once the webhook server is serving conversion requests
+		go migrateWorkspaces(ctx, c, storageVersion)
+	}
+	return nil
+}
+
+// getWebhookConversion returns the conversion settings of the Workspace CRD, including the current CA bundle of the
+// webhook server
+func getWebhookConversion() map[string]interface{} {
+	return map[string]interface{}{
+		"strategy": "Webhook",
+		"webhookClientConfig": map[string]interface{}{
+			"service": map[string]interface{}{
+				"name":      server.ServiceName,
+				"namespace": server.ServiceNamespace,
+				"path":      conversionWebhookPath,
+			},
+			"caBundle": base64.StdEncoding.EncodeToString(server.CABundle),
+		},
+		"conversionReviewVersions": []interface{}{"v1beta1"},
+	}
+}
+
+func getWorkspaceCRD(ctx context.Context, c client.Client) (*unstructured.Unstructured, error) {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	err := c.Get(ctx, types.NamespacedName{Name: workspaceCRDName}, crd)
+	return crd, err
+}
+
+// updateWorkspaceCRD sets the conversion strategy and the storage version of the Workspace CRD, updating it in the
+// cluster only if either changed
+func updateWorkspaceCRD(ctx context.Context, c client.Client, crd *unstructured.Unstructured, crdConversion map[string]interface{}, storageVersion string) error {
+	updated := crd.DeepCopy()
+
+	versions, _, err := unstructured.NestedSlice(updated.Object, "spec", "versions")
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, version := range versions {
+		version := version.(map[string]interface{})
+		isStorage := version["name"] == storageVersion
+		version["storage"] = isStorage
+		found = found || isStorage
+	}
+	if !found {
+		return fmt.Errorf("storage version %s is not defined in CRD %s", storageVersion, workspaceCRDName)
+	}
+	if err := unstructured.SetNestedSlice(updated.Object, versions, "spec", "versions"); err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(updated.Object, crdConversion, "spec", "conversion"); err != nil {
+		return err
+	}
+	if crdConversion["strategy"] == "Webhook" {
+		// Required by the API server for webhook conversion
+		if err := unstructured.SetNestedField(updated.Object, false, "spec", "preserveUnknownFields"); err != nil {
+			return err
+		}
+	}
+
+	if reflect.DeepEqual(crd.Object, updated.Object) {
+		return nil
+	}
+	if err := c.Update(ctx, updated); err != nil {
+		return err
+	}
+	log.Info("Updated workspace CRD", "conversion", crdConversion["strategy"], "storageVersion", storageVersion)
+	return nil
+}
+
+func isOnlyVersion(versions []string, version string) bool {
+	return len(versions) == 1 && versions[0] == version
+}
@@ -0,0 +1,176 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// conversionReview mirrors the apiextensions.k8s.io/v1beta1 ConversionReview, which is sent by the API server to
+// convert custom resources between versions
+type conversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *conversionRequest  `json:"request,omitempty"`
+	Response        *conversionResponse `json:"response,omitempty"`
+}
+
+type conversionRequest struct {
+	UID               types.UID              `json:"uid"`
+	DesiredAPIVersion string                 `json:"desiredAPIVersion"`
+	Objects           []runtime.RawExtension `json:"objects"`
+}
+
+type conversionResponse struct {
+	UID              types.UID              `json:"uid"`
+	ConvertedObjects []runtime.RawExtension `json:"convertedObjects"`
+	Result           metav1.Status          `json:"result"`
+}
+
+// Webhook converts objects between the versions of the Workspace API. Versions other than the hub (v1alpha1) must
+// implement conversion.Convertible.
+type Webhook struct {
+	scheme *runtime.Scheme
+}
+
+func NewWebhook() (*Webhook, error) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := v1alpha2.SchemeBuilder.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return &Webhook{scheme: scheme}, nil
+}
+
+func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &conversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		log.Error(err, "Failed to decode conversion request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := &conversionResponse{UID: review.Request.UID}
+	convertedObjects, err := wh.convertObjects(review.Request.Objects, review.Request.DesiredAPIVersion)
+	if err != nil {
+		log.Error(err, "Failed to convert objects", "desiredAPIVersion", review.Request.DesiredAPIVersion)
+		response.Result = metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		}
+	} else {
+		response.ConvertedObjects = convertedObjects
+		response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	}
+
+	review.Request = nil
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Error(err, "Failed to encode conversion response")
+	}
+}
+
+func (wh *Webhook) convertObjects(objects []runtime.RawExtension, desiredAPIVersion string) ([]runtime.RawExtension, error) {
+	desiredGV, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	var convertedObjects []runtime.RawExtension
+	for _, object := range objects {
+		src, err := wh.decode(object.Raw)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := wh.convert(src, desiredGV.WithKind(src.GetObjectKind().GroupVersionKind().Kind))
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(dst)
+		if err != nil {
+			return nil, err
+		}
+		convertedObjects = append(convertedObjects, runtime.RawExtension{Raw: raw})
+	}
+	return convertedObjects, nil
+}
+
+func (wh *Webhook) decode(raw []byte) (runtime.Object, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, typeMeta); err != nil {
+		return nil, err
+	}
+	gvk := typeMeta.GroupVersionKind()
+	object, err := wh.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, object); err != nil {
+		return nil, err
+	}
+	object.GetObjectKind().SetGroupVersionKind(gvk)
+	return object, nil
+}
+
+func (wh *Webhook) convert(src runtime.Object, dstGVK schema.GroupVersionKind) (runtime.Object, error) {
+	srcGVK := src.GetObjectKind().GroupVersionKind()
+	if srcGVK == dstGVK {
+		return src, nil
+	}
+	dst, err := wh.scheme.New(dstGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case isHub(src):
+		convertible, ok := dst.(conversion.Convertible)
+		if !ok {
+			return nil, fmt.Errorf("%s is not convertible", dstGVK)
+		}
+		err = convertible.ConvertFrom(src.(conversion.Hub))
+	case isHub(dst):
+		convertible, ok := src.(conversion.Convertible)
+		if !ok {
+			return nil, fmt.Errorf("%s is not convertible", srcGVK)
+		}
+		err = convertible.ConvertTo(dst.(conversion.Hub))
+	default:
+		// Convert through the hub version
+		var hub runtime.Object
+		hub, err = wh.convert(src, v1alpha1.SchemeGroupVersion.WithKind(srcGVK.Kind))
+		if err == nil {
+			dst, err = wh.convert(hub, dstGVK)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	dst.GetObjectKind().SetGroupVersionKind(dstGVK)
+	return dst, nil
+}
+
+func isHub(object runtime.Object) bool {
+	_, ok := object.(conversion.Hub)
+	return ok
+}
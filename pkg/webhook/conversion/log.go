@@ -0,0 +1,16 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package conversion
+
+import logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+var log = logf.Log.WithName("webhook.conversion")
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package conversion
+
+import (
+	"context"
+	"time"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const migrationRetryInterval = 10 * time.Second
+
+// migrateWorkspaces rewrites all workspaces in the cluster so that they are stored in storageVersion, and then removes
+// other versions from the stored versions of the Workspace CRD. Failures, e.g. while the webhook server is not yet
+// serving, are retried until the migration succeeds.
+func migrateWorkspaces(ctx context.Context, c client.Client, storageVersion string) {
+	log.Info("Migrating workspaces to storage version", "storageVersion", storageVersion)
+	_ = wait.PollImmediateInfinite(migrationRetryInterval, func() (bool, error) {
+		if err := rewriteWorkspaces(ctx, c, storageVersion); err != nil {
+			log.Info("Failed to migrate workspaces; retrying", "error", err.Error())
+			return false, nil
+		}
+		if err := setStoredVersions(ctx, c, storageVersion); err != nil {
+			log.Info("Failed to update stored versions of workspace CRD; retrying", "error", err.Error())
+			return false, nil
+		}
+		return true, nil
+	})
+	log.Info("Migrated workspaces to storage version", "storageVersion", storageVersion)
+}
+
+// rewriteWorkspaces updates each workspace without changes, which makes the API server store it in the current
+// storage version
+func rewriteWorkspaces(ctx context.Context, c client.Client, storageVersion string) error {
+	workspaces := &unstructured.UnstructuredList{}
+	workspaces.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   v1alpha1.SchemeGroupVersion.Group,
+		Version: storageVersion,
+		Kind:    "WorkspaceList",
+	})
+	if err := c.List(ctx, workspaces); err != nil {
+		return err
+	}
+	for _, workspace := range workspaces.Items {
+		err := c.Update(ctx, &workspace)
+		// A conflicting update means the workspace was already rewritten by someone else
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func setStoredVersions(ctx context.Context, c client.Client, storageVersion string) error {
+	crd, err := getWorkspaceCRD(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion}, "status", "storedVersions"); err != nil {
+		return err
+	}
+	return c.Status().Update(ctx, crd)
+}
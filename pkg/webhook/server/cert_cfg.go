@@ -19,7 +19,6 @@ import (
 )
 
 const (
-	secureServiceName = "workspace-controller"
 	certConfigMapName = "che-workspace-controller-secure-service"
 	certSecretName    = "workspace-controller"
 	certVolumeName    = "webhook-tls-certs"
@@ -32,7 +31,7 @@ func getSecureServiceSpec(namespace string) *corev1.Service {
 	port := int32(443)
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      secureServiceName,
+			Name:      ServiceName,
 			Namespace: namespace,
 			Labels:    label,
 			Annotations: map[string]string{
@@ -47,7 +46,7 @@ func getSecureServiceSpec(namespace string) *corev1.Service {
 					TargetPort: intstr.FromString(webhookServerName),
 				},
 			},
-			Selector: label,
+			Selector: serviceSelector,
 		},
 	}
 
@@ -0,0 +1,349 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/che-incubator/che-workspace-operator/internal/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// selfSignedCertSecretName is the Secret storing the self-signed CA and the webhook server's certificate when the
+	// controller manages them itself
+	selfSignedCertSecretName = "che-workspace-controller-webhook-certs"
+	caCertKey                = "ca.crt"
+	caKeyKey                 = "ca.key"
+
+	caValidity          = 3 * 365 * 24 * time.Hour
+	servingCertValidity = 365 * 24 * time.Hour
+	// servingCertRenewBefore is how long before its expiry the webhook server's certificate is renewed
+	servingCertRenewBefore = 30 * 24 * time.Hour
+	// certCheckInterval is how often the certificates are checked for renewal, and for renewals by other replicas of
+	// the controller
+	certCheckInterval = time.Hour
+)
+
+var caBundleUpdateHandlers []func(context.Context) error
+
+// OnCABundleUpdate registers a function that is called once CABundle is updated after the self-signed CA is rotated,
+// so that webhook configurations can be updated with the new CA bundle. Handlers are not called on OpenShift, where
+// the service CA is managed by the cluster. If a handler fails, CABundle is reverted and all handlers are called again
+// on the next check.
+func OnCABundleUpdate(handler func(context.Context) error) {
+	caBundleUpdateHandlers = append(caBundleUpdateHandlers, handler)
+}
+
+// setUpSelfSignedCerts sets up TLS for the webhook server on clusters that do not provide serving certificates for
+// services. A self-signed CA and a certificate for the webhook server's service are stored in a Secret, shared by
+// all replicas of the controller, and written to the webhook server's certificate directory. They are renewed
+// before they expire; the webhook server reloads the certificate when the files change, so no restart is needed.
+func setUpSelfSignedCerts(mgr manager.Manager, ctx context.Context) error {
+	crclient, err := controller.CreateClient()
+	if err != nil {
+		return err
+	}
+	namespace := ServiceNamespace
+	if err := syncService(ctx, crclient, namespace); err != nil {
+		return err
+	}
+
+	secret, err := syncCertSecret(ctx, crclient, namespace, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := writeCertFiles(secret); err != nil {
+		return err
+	}
+	CABundle = secret.Data[caCertKey]
+	log.Info("Set up self-signed certificates for webhook server", "secret", selfSignedCertSecretName)
+
+	return mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		ticker := time.NewTicker(certCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return nil
+			case <-ticker.C:
+				if err := rotateCerts(ctx, crclient, namespace); err != nil {
+					log.Error(err, "Failed to rotate webhook server certificates")
+				}
+			}
+		}
+	}))
+}
+
+// rotateCerts renews the certificates in the Secret if needed and applies the Secret's certificates if they differ
+// from the ones in use, which may have been renewed by another replica. The CA bundle is updated in webhook
+// configurations before the new certificate is served, and contains the previous CA, so that the API server trusts
+// the webhook server throughout the rotation. The new certificate is not served until all webhook configurations are
+// updated.
+func rotateCerts(ctx context.Context, crclient client.Client, namespace string) error {
+	secret, err := syncCertSecret(ctx, crclient, namespace, time.Now())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(secret.Data[caCertKey], CABundle) {
+		log.Info("Webhook server CA was rotated. Updating webhook configurations")
+		appliedCABundle := CABundle
+		CABundle = secret.Data[caCertKey]
+		for _, handler := range caBundleUpdateHandlers {
+			if err := handler(ctx); err != nil {
+				// Keep serving the current certificate and retry on the next check, as some webhook configurations
+				// may not trust the new CA yet
+				CABundle = appliedCABundle
+				return err
+			}
+		}
+	}
+	current, err := ioutil.ReadFile(filepath.Join(webhookServerCertDir, corev1.TLSCertKey))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(current, secret.Data[corev1.TLSCertKey]) {
+		log.Info("Webhook server certificate was renewed. Reloading certificate")
+		return writeCertFiles(secret)
+	}
+	return nil
+}
+
+// syncCertSecret returns the Secret storing the self-signed certificates, creating it or renewing its certificates
+// if needed
+func syncCertSecret(ctx context.Context, crclient client.Client, namespace string, now time.Time) (*corev1.Secret, error) {
+	dnsNames := getServiceDNSNames(namespace)
+	secret := &corev1.Secret{}
+	err := crclient.Get(ctx, types.NamespacedName{Name: selfSignedCertSecretName, Namespace: namespace}, secret)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		data, _, err := renewCerts(nil, dnsNames, now)
+		if err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      selfSignedCertSecretName,
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "che-workspace-controller"},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := crclient.Create(ctx, secret); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Created by another replica in the meantime
+				return syncCertSecret(ctx, crclient, namespace, now)
+			}
+			return nil, err
+		}
+		log.Info("Created self-signed certificates for webhook server")
+		return secret, nil
+	}
+
+	data, renewed, err := renewCerts(secret.Data, dnsNames, now)
+	if err != nil {
+		return nil, err
+	}
+	if !renewed {
+		return secret, nil
+	}
+	secret.Data = data
+	if err := crclient.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+	log.Info("Renewed self-signed certificates for webhook server")
+	return secret, nil
+}
+
+// renewCerts returns the certificate data to store in the Secret, and whether it differs from the current data. The
+// CA is renewed when it would expire before a newly issued serving certificate; the previous CA is kept in the CA
+// bundle until it expires. The serving certificate is renewed when it is about to expire, was not issued by the
+// current CA or does not cover the service's DNS names.
+func renewCerts(data map[string][]byte, dnsNames []string, now time.Time) (map[string][]byte, bool, error) {
+	renewed := false
+	caCert, caKey, err := parseCertAndKey(data[caCertKey], data[caKeyKey])
+	if err != nil || now.Add(servingCertValidity).After(caCert.NotAfter) {
+		caCert, caKey, err = generateCA(now)
+		if err != nil {
+			return nil, false, err
+		}
+		renewed = true
+	}
+
+	servingCert, _, err := parseCertAndKey(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if renewed || err != nil || now.Add(servingCertRenewBefore).After(servingCert.NotAfter) ||
+		servingCert.CheckSignatureFrom(caCert) != nil || !equalStrings(servingCert.DNSNames, dnsNames) {
+		certPEM, keyPEM, err := generateServingCert(caCert, caKey, dnsNames, now)
+		if err != nil {
+			return nil, false, err
+		}
+		caBundle := encodeCert(caCert)
+		if previousCAs, ok := data[caCertKey]; ok {
+			caBundle = append(caBundle, getValidCerts(previousCAs, caCert, now)...)
+		}
+		return map[string][]byte{
+			caCertKey:               caBundle,
+			caKeyKey:                pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(caKey)}),
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		}, true, nil
+	}
+	return data, false, nil
+}
+
+func generateCA(now time.Time) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("Admission Workspace Controller Webhook CA %d", now.Unix())},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	return cert, key, err
+}
+
+func generateServingCert(caCert *x509.Certificate, caKey *rsa.PrivateKey, dnsNames []string, now time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(servingCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
+
+// parseCertAndKey parses the first certificate of a PEM bundle and, if set, an RSA private key
+func parseCertAndKey(certPEM, keyPEM []byte) (*x509.Certificate, *rsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, errors.New("certificate is missing")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, errors.New("private key is missing")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+// getValidCerts returns the certificates of a PEM bundle that have not expired, except the given certificate
+func getValidCerts(bundle []byte, except *x509.Certificate, now time.Time) []byte {
+	var valid []byte
+	for block, rest := pem.Decode(bundle); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || cert.Equal(except) || now.After(cert.NotAfter) {
+			continue
+		}
+		valid = append(valid, pem.EncodeToMemory(block)...)
+	}
+	return valid
+}
+
+func encodeCert(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func getServiceDNSNames(namespace string) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", ServiceName, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", ServiceName, namespace),
+		fmt.Sprintf("%s.%s", ServiceName, namespace),
+		ServiceName,
+	}
+}
+
+// writeCertFiles writes the certificates of the Secret to the webhook server's certificate directory. The private key
+// is written first, so that the certificate matches it once it is reloaded.
+func writeCertFiles(secret *corev1.Secret) error {
+	if err := os.MkdirAll(webhookServerCertDir, 0700); err != nil {
+		return err
+	}
+	for _, key := range []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey, caCertKey} {
+		if err := ioutil.WriteFile(filepath.Join(webhookServerCertDir, key), secret.Data[key], 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
@@ -16,6 +16,7 @@ import (
 	"os"
 
 	"github.com/che-incubator/che-workspace-operator/internal/cluster"
+	"github.com/che-incubator/che-workspace-operator/internal/controller"
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
@@ -52,8 +53,19 @@ func ConfigureWebhookServer(mgr manager.Manager, ctx context.Context) error {
 		return nil
 	}
 
+	crclient, err := controller.CreateClient()
+	if err != nil {
+		return err
+	}
+	if err := resolveService(ctx, crclient); err != nil {
+		return err
+	}
+
 	CABundle, err = ioutil.ReadFile(webhookServerCertDir + "/ca.crt")
-	if os.IsNotExist(err) {
+	if os.IsNotExist(err) && !config.ControllerCfg.IsOpenShift() {
+		log.Info("CA certificate is not found. Webhook server will use self-signed certificates")
+		err = setUpSelfSignedCerts(mgr, ctx)
+	} else if os.IsNotExist(err) {
 		log.Info("CA certificate is not found. Webhook server will now attempt to setup")
 		err = InitWebhookServer(ctx)
 		if err != nil {
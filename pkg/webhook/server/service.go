@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package server
+
+import (
+	"context"
+
+	"github.com/che-incubator/che-workspace-operator/internal/controller"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"k8s.io/api/admissionregistration/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceName and ServiceNamespace identify the service that exposes the webhook server. They are resolved when the
+// webhook server is configured.
+var ServiceName, ServiceNamespace string
+
+// serviceSelector selects the pods of the controller's deployment
+var serviceSelector = map[string]string{"app": "che-workspace-controller"}
+
+// resolveService resolves the webhook server's service: it is named as configured in the controller config and is
+// created in the controller's namespace, selecting the pods of the controller's deployment. When the controller is
+// run locally, the namespace is the watched namespace and the default selector is used.
+func resolveService(ctx context.Context, crclient client.Client) error {
+	namespace, err := getOperatorNamespace()
+	if err != nil {
+		return err
+	}
+	ServiceName = config.ControllerCfg.GetWebhookServiceName()
+	ServiceNamespace = namespace
+
+	deployment, err := controller.FindControllerDeployment(ctx, crclient)
+	if err == k8sutil.ErrRunLocal {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if deployment != nil && deployment.Spec.Selector != nil && len(deployment.Spec.Selector.MatchLabels) > 0 {
+		serviceSelector = deployment.Spec.Selector.MatchLabels
+	}
+	log.Info("Resolved webhook server service", "name", ServiceName, "namespace", ServiceNamespace)
+	return nil
+}
+
+// GetServiceReference returns a reference to the webhook server's service for webhooks served on the given path
+func GetServiceReference(path string) *v1beta1.ServiceReference {
+	return &v1beta1.ServiceReference{
+		Name:      ServiceName,
+		Namespace: ServiceNamespace,
+		Path:      &path,
+	}
+}
@@ -36,13 +36,7 @@ func InitWebhookServer(ctx context.Context) error {
 		return err
 	}
 
-	ns, err := k8sutil.GetOperatorNamespace()
-	if err == k8sutil.ErrRunLocal {
-		ns = os.Getenv("WATCH_NAMESPACE")
-		log.Info(fmt.Sprintf("Running operator in local mode; watching namespace %s", config.ConfigMapReference.Namespace))
-	} else if err != nil {
-		return err
-	}
+	ns := ServiceNamespace
 
 	err = syncService(ctx, crclient, ns)
 	if err != nil {
@@ -62,6 +56,16 @@ func InitWebhookServer(ctx context.Context) error {
 	return errors.New("TLS is setup. Controller needs to restart to apply changes")
 }
 
+func getOperatorNamespace() (string, error) {
+	ns, err := k8sutil.GetOperatorNamespace()
+	if err == k8sutil.ErrRunLocal {
+		ns = os.Getenv("WATCH_NAMESPACE")
+		log.Info(fmt.Sprintf("Running operator in local mode; watching namespace %s", config.ConfigMapReference.Namespace))
+		return ns, nil
+	}
+	return ns, err
+}
+
 func syncService(ctx context.Context, crclient client.Client, namespace string) error {
 	secureService := getSecureServiceSpec(namespace)
 	if err := crclient.Create(ctx, secureService); err != nil {
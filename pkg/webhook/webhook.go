@@ -22,7 +22,7 @@ import (
 var log = logf.Log.WithName("webhook")
 
 // configureWebhookTasks is a list of functions to add set webhook up and add them to the Manager
-var configureWebhookTasks []func(context.Context) error
+var configureWebhookTasks []func(manager.Manager, context.Context) error
 
 // SetUpWebhooks sets up Webhook server and registers webhooks configurations
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
@@ -35,7 +35,7 @@ func SetUpWebhooks(mgr manager.Manager, ctx context.Context) error {
 	}
 
 	for _, f := range configureWebhookTasks {
-		if err := f(ctx); err != nil {
+		if err := f(mgr, ctx); err != nil {
 			return err
 		}
 	}
@@ -15,16 +15,25 @@ import (
 	"context"
 
 	"github.com/che-incubator/che-workspace-operator/internal/controller"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	"github.com/che-incubator/che-workspace-operator/pkg/webhook/server"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"k8s.io/api/admissionregistration/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// legacyWebhookCfgNames are the names of workspace webhook configurations created by previous versions of the
+// controller, which did not label them
+var legacyWebhookCfgNames = []string{"workspace.che.eclipse.org", config.MutateWebhookCfgName}
+
 //Configure configures mutate/validating webhooks that provides exec access into workspace for creator only
-func Configure(ctx context.Context) error {
+func Configure(mgr manager.Manager, ctx context.Context) error {
 	log.Info("Configuring workspace webhooks")
 	c, err := controller.CreateClient()
 	if err != nil {
@@ -33,36 +42,49 @@ func Configure(ctx context.Context) error {
 
 	if !server.IsSetUp() {
 		log.Info("Webhooks server is not set up. Cleaning up webhook configurations")
-
-		if err := c.Delete(ctx, &v1beta1.MutatingWebhookConfiguration{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: mutateWebhookCfgName,
-			}}); err != nil {
-			if !apierrors.IsNotFound(err) {
-				return err
-			}
-		}
-		if err = c.Delete(ctx, &v1beta1.ValidatingWebhookConfiguration{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: validateWebhookCfgName,
-			}}); err != nil {
-			if !apierrors.IsNotFound(err) {
-				return err
-			}
+		if err := deleteStaleWebhookCfgs(ctx, c, true); err != nil {
+			return err
 		}
-
 		log.Info("Existing workspace related webhook configurations are removed")
 		return nil
 	}
 
-	mutateWebhookCfg := buildMutateWebhookCfg()
-
 	ownRef, err := controller.FindControllerOwner(ctx, c)
 	if err != nil {
 		return err
 	}
 
-	//TODO we need to watch owned webhook configuration and clean up old ones
+	if err := syncWebhookCfgs(ctx, c, ownRef); err != nil {
+		return err
+	}
+	if err := deleteStaleWebhookCfgs(ctx, c, false); err != nil {
+		return err
+	}
+	server.OnCABundleUpdate(func(ctx context.Context) error {
+		return syncWebhookCfgs(ctx, c, ownRef)
+	})
+	if err := addWebhookCfgController(mgr, c, ownRef); err != nil {
+		return err
+	}
+
+	controllerUsername, err := controller.GetControllerUsername(ctx, c)
+	if err == k8sutil.ErrRunLocal {
+		log.Info("Running operator in local mode; changes to workspace objects are only allowed for workspace admins")
+	} else if err != nil {
+		return err
+	}
+
+	server.GetWebhookServer().Register(mutateWebhookPath, &webhook.Admission{Handler: NewResourcesMutator()})
+	server.GetWebhookServer().Register(validateWebhookPath, &webhook.Admission{Handler: NewResourcesValidator()})
+	server.GetWebhookServer().Register(validateOwnedWebhookPath, &webhook.Admission{Handler: NewOwnedObjectsValidator(controllerUsername)})
+
+	return nil
+}
+
+// syncWebhookCfgs creates or updates the workspace webhook configurations, including the current CA bundle of the
+// webhook server
+func syncWebhookCfgs(ctx context.Context, c client.Client, ownRef *metav1.OwnerReference) error {
+	mutateWebhookCfg := buildMutateWebhookCfg()
 
 	//TODO For some reasons it's still possible to update reference by user
 	//TODO Investigate if we can block it. The same issue is valid for Deployment owner
@@ -79,19 +101,22 @@ func Configure(ctx context.Context) error {
 			Name:      mutateWebhookCfg.Name,
 			Namespace: mutateWebhookCfg.Namespace,
 		}, existingCfg)
+		if err != nil {
+			return err
+		}
 
 		mutateWebhookCfg.ResourceVersion = existingCfg.ResourceVersion
 		err = c.Update(ctx, mutateWebhookCfg)
 		if err != nil {
 			return err
 		}
-		log.Info("Updated workspace mutating webhook configuration")
+		if mutateWebhookCfg.ResourceVersion != existingCfg.ResourceVersion {
+			log.Info("Updated workspace mutating webhook configuration")
+		}
 	} else {
 		log.Info("Created workspace mutating webhook configuration")
 	}
 
-	server.GetWebhookServer().Register(mutateWebhookPath, &webhook.Admission{Handler: NewResourcesMutator()})
-
 	validateWebhookCfg := buildValidatingWebhookCfg()
 	validateWebhookCfg.SetOwnerReferences([]metav1.OwnerReference{*ownRef})
 
@@ -106,18 +131,116 @@ func Configure(ctx context.Context) error {
 			Name:      validateWebhookCfg.Name,
 			Namespace: validateWebhookCfg.Namespace,
 		}, existingCfg)
+		if err != nil {
+			return err
+		}
 
 		validateWebhookCfg.ResourceVersion = existingCfg.ResourceVersion
 		err = c.Update(ctx, validateWebhookCfg)
 		if err != nil {
 			return err
 		}
-		log.Info("Updated workspace validating webhook configuration")
+		if validateWebhookCfg.ResourceVersion != existingCfg.ResourceVersion {
+			log.Info("Updated workspace validating webhook configuration")
+		}
 	} else {
 		log.Info("Created workspace validating webhook configuration")
 	}
+	return nil
+}
 
-	server.GetWebhookServer().Register(validateWebhookPath, &webhook.Admission{Handler: NewResourcesValidator()})
+// deleteStaleWebhookCfgs removes workspace webhook configurations left by previous installs of the controller: the
+// ones with legacy names, the ones labeled as managed by the controller and the ones defining the controller's
+// webhooks under another name, which would conflict with the current configurations. If all is true, the current
+// configurations are removed as well.
+func deleteStaleWebhookCfgs(ctx context.Context, c client.Client, all bool) error {
+	mutatingCfgs := &v1beta1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, mutatingCfgs); err != nil {
+		return err
+	}
+	for idx := range mutatingCfgs.Items {
+		cfg := &mutatingCfgs.Items[idx]
+		if isStaleWebhookCfg(cfg, all) {
+			if err := deleteWebhookCfg(ctx, c, cfg, cfg.Name); err != nil {
+				return err
+			}
+		}
+	}
 
+	validatingCfgs := &v1beta1.ValidatingWebhookConfigurationList{}
+	if err := c.List(ctx, validatingCfgs); err != nil {
+		return err
+	}
+	for idx := range validatingCfgs.Items {
+		cfg := &validatingCfgs.Items[idx]
+		if isStaleWebhookCfg(cfg, all) {
+			if err := deleteWebhookCfg(ctx, c, cfg, cfg.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteWebhookCfg(ctx context.Context, c client.Client, cfg runtime.Object, name string) error {
+	if err := c.Delete(ctx, cfg); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	log.Info("Removed stale workspace webhook configuration", "name", name)
 	return nil
 }
+
+// isStaleWebhookCfg returns true if cfg is a workspace webhook configuration that is not built by this version of the
+// controller, or any workspace webhook configuration if all is true
+func isStaleWebhookCfg(cfg runtime.Object, all bool) bool {
+	if !isWorkspaceWebhookCfg(cfg) {
+		return false
+	}
+	name := cfg.(metav1.Object).GetName()
+	return all || (name != mutateWebhookCfgName && name != validateWebhookCfgName)
+}
+
+// isWorkspaceWebhookCfg returns true if cfg is a mutating or validating webhook configuration that is, or was, managed
+// by the controller
+func isWorkspaceWebhookCfg(cfg runtime.Object) bool {
+	meta, ok := cfg.(metav1.Object)
+	if !ok {
+		return false
+	}
+	if meta.GetName() == mutateWebhookCfgName || meta.GetName() == validateWebhookCfgName {
+		return true
+	}
+	for _, name := range legacyWebhookCfgNames {
+		if meta.GetName() == name {
+			return true
+		}
+	}
+	if _, ok := meta.GetLabels()[config.WebhookConfigurationLabel]; ok {
+		return true
+	}
+	workspaceWebhooks := map[string]bool{}
+	for _, webhook := range buildMutateWebhookCfg().Webhooks {
+		workspaceWebhooks[webhook.Name] = true
+	}
+	for _, webhook := range buildValidatingWebhookCfg().Webhooks {
+		workspaceWebhooks[webhook.Name] = true
+	}
+	switch cfg := cfg.(type) {
+	case *v1beta1.MutatingWebhookConfiguration:
+		for _, webhook := range cfg.Webhooks {
+			if workspaceWebhooks[webhook.Name] {
+				return true
+			}
+		}
+	case *v1beta1.ValidatingWebhookConfiguration:
+		for _, webhook := range cfg.Webhooks {
+			if workspaceWebhooks[webhook.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// maxSpecDiffLength bounds the size of the spec diff stored in WorkspaceEvents, as workspace specs may embed
+// large devfiles
+const maxSpecDiffLength = 8192
+
+// recordWorkspaceUpdate logs who requested a change of the spec of a workspace and how, and records the requested
+// change as a WorkspaceEvent owned by the workspace, of which only the latest are kept. It is called once all checks of
+// the webhook server passed, but the change may still be rejected afterwards by the API server. Updates that do not
+// change the spec (e.g. of labels) and dry-run requests are not recorded. Failures to record the change are logged but
+// do not fail the request, so that workspaces can still be started and stopped.
+func (h *WebhookHandler) recordWorkspaceUpdate(ctx context.Context, req admission.Request, oldWksp, newWksp *v1alpha1.Workspace) {
+	if req.DryRun != nil && *req.DryRun {
+		return
+	}
+	specDiff := cmp.Diff(oldWksp.Spec, newWksp.Spec)
+	if specDiff == "" {
+		return
+	}
+	operation := v1alpha1.WorkspaceOperationUpdate
+	if !oldWksp.Spec.Started && newWksp.Spec.Started {
+		operation = v1alpha1.WorkspaceOperationStart
+	} else if oldWksp.Spec.Started && !newWksp.Spec.Started {
+		operation = v1alpha1.WorkspaceOperationStop
+	}
+
+	if len(specDiff) > maxSpecDiffLength {
+		specDiff = specDiff[:maxSpecDiffLength] + "\n... (truncated)"
+	}
+
+	log.Info("Workspace "+strings.ToLower(string(operation))+" requested",
+		"user", req.UserInfo.Username,
+		"uid", req.UserInfo.UID,
+		"groups", req.UserInfo.Groups,
+		"namespace", req.Namespace,
+		"workspace", oldWksp.Name,
+		"workspaceId", oldWksp.Status.WorkspaceId,
+		"operation", operation,
+		"specDiff", specDiff)
+
+	maxCount, err := config.ControllerCfg.GetWorkspaceEventsMaxCount()
+	if err != nil {
+		log.Error(err, "Failed to record workspace event")
+		return
+	}
+	if maxCount == 0 {
+		return
+	}
+	event := &v1alpha1.WorkspaceEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: oldWksp.Name + "-",
+			Namespace:    oldWksp.Namespace,
+			Labels: map[string]string{
+				config.WorkspaceNameLabel: oldWksp.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(oldWksp, v1alpha1.SchemeGroupVersion.WithKind("Workspace")),
+			},
+		},
+		Spec: v1alpha1.WorkspaceEventSpec{
+			WorkspaceName: oldWksp.Name,
+			WorkspaceId:   oldWksp.Status.WorkspaceId,
+			Operation:     operation,
+			Username:      req.UserInfo.Username,
+			UserUID:       req.UserInfo.UID,
+			Groups:        req.UserInfo.Groups,
+			Timestamp:     metav1.Now(),
+			SpecDiff:      specDiff,
+		},
+	}
+	if oldWksp.Status.WorkspaceId != "" {
+		event.Labels[config.WorkspaceIDLabel] = oldWksp.Status.WorkspaceId
+	}
+	if err := h.Client.Create(ctx, event); err != nil {
+		log.Error(err, "Failed to record workspace event", "namespace", oldWksp.Namespace, "workspace", oldWksp.Name)
+		return
+	}
+	if err := h.pruneWorkspaceEvents(ctx, oldWksp, maxCount); err != nil {
+		log.Error(err, "Failed to delete old workspace events", "namespace", oldWksp.Namespace, "workspace", oldWksp.Name)
+	}
+}
+
+// pruneWorkspaceEvents deletes the oldest events of a workspace so that at most maxCount events are kept
+func (h *WebhookHandler) pruneWorkspaceEvents(ctx context.Context, wksp *v1alpha1.Workspace, maxCount int) error {
+	events := &v1alpha1.WorkspaceEventList{}
+	err := h.Client.List(ctx, events, client.InNamespace(wksp.Namespace), client.MatchingLabels{config.WorkspaceNameLabel: wksp.Name})
+	if err != nil {
+		return err
+	}
+	var owned []v1alpha1.WorkspaceEvent
+	for _, event := range events.Items {
+		if metav1.IsControlledBy(&event, wksp) {
+			owned = append(owned, event)
+		}
+	}
+	if len(owned) <= maxCount {
+		return nil
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].Spec.Timestamp.Before(&owned[j].Spec.Timestamp)
+	})
+	for idx := range owned[:len(owned)-maxCount] {
+		err := h.Client.Delete(ctx, &owned[idx])
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
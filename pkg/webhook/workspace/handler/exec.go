@@ -12,18 +12,42 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var V1PodExecOptionKind = metav1.GroupVersionKind{Kind: "PodExecOptions", Group: "", Version: "v1"}
+var V1PodAttachOptionsKind = metav1.GroupVersionKind{Kind: "PodAttachOptions", Group: "", Version: "v1"}
+var V1PodPortForwardOptionsKind = metav1.GroupVersionKind{Kind: "PodPortForwardOptions", Group: "", Version: "v1"}
+
+// EphemeralContainersSubResource is the pods subresource used to add ephemeral containers to a running pod
+const EphemeralContainersSubResource = "ephemeralcontainers"
+
+// podAccessTypes maps the pods subresources that give access to the processes, network or file system of a pod to
+// the name of the access in audit logs and denial messages
+var podAccessTypes = map[string]string{
+	"exec":                         "exec",
+	"attach":                       "attach",
+	"portforward":                  "port-forward",
+	EphemeralContainersSubResource: "ephemeral container",
+}
+
+// ValidatePodAccess allows exec, attach, port-forward and the addition of ephemeral containers on workspace pods only
+// for the workspace creator and the users the workspace is shared with. Access to workspace pods is logged.
+func (h *WebhookHandler) ValidatePodAccess(ctx context.Context, req admission.Request) admission.Response {
+	access, ok := podAccessTypes[req.SubResource]
+	if !ok {
+		return admission.Denied(fmt.Sprintf("Access through pods/%s is not supported", req.SubResource))
+	}
 
-func (h *WebhookHandler) ValidateExecOnConnect(ctx context.Context, req admission.Request) admission.Response {
 	p := corev1.Pod{}
 	err := h.Client.Get(ctx, types.NamespacedName{
 		Name:      req.Name,
@@ -34,19 +58,66 @@ func (h *WebhookHandler) ValidateExecOnConnect(ctx context.Context, req admissio
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
-	_, ok := p.Labels[config.WorkspaceIDLabel]
+	_, ok = p.Labels[config.WorkspaceIDLabel]
 	if !ok {
 		return admission.Allowed("It's not workspace related pod")
 	}
 
+	resp := h.checkPodAccess(ctx, req, &p, access)
+	auditPodAccess(req, &p, access, resp)
+	return resp
+}
+
+func (h *WebhookHandler) checkPodAccess(ctx context.Context, req admission.Request, p *corev1.Pod, access string) admission.Response {
 	creator, ok := p.Labels[config.WorkspaceCreatorLabel]
 	if !ok {
 		return admission.Denied("The workspace info is missing in the workspace-related pod")
 	}
 
-	if creator != req.UserInfo.UID {
-		return admission.Denied("The only workspace creator has exec access")
+	if creator == req.UserInfo.UID {
+		return admission.Allowed("The current user and workspace are matched")
+	}
+
+	workspaceName, ok := p.Labels[config.WorkspaceNameLabel]
+	if !ok {
+		return admission.Denied(fmt.Sprintf("The only workspace creator has %s access", access))
+	}
+	wksp := &v1alpha1.Workspace{}
+	err := h.Client.Get(ctx, types.NamespacedName{
+		Name:      workspaceName,
+		Namespace: req.Namespace,
+	}, wksp)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return admission.Denied(fmt.Sprintf("The only workspace creator has %s access", access))
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if wksp.Labels[config.WorkspaceCreatorLabel] != creator || !isSharedWith(wksp, req.UserInfo) {
+		return admission.Denied(fmt.Sprintf("The only workspace creator and the users the workspace is shared with have %s access", access))
 	}
 
-	return admission.Allowed("The current user and workspace are matched")
+	return admission.Allowed("The workspace is shared with the current user")
+}
+
+// auditPodAccess logs who was allowed or denied access to a workspace pod, and why
+func auditPodAccess(req admission.Request, p *corev1.Pod, access string, resp admission.Response) {
+	reason := ""
+	if resp.Result != nil {
+		reason = resp.Result.Message
+	}
+	decision := "denied"
+	if resp.Allowed {
+		decision = "allowed"
+	}
+	log.Info(fmt.Sprintf("Workspace pod %s access %s", access, decision),
+		"user", req.UserInfo.Username,
+		"uid", req.UserInfo.UID,
+		"groups", req.UserInfo.Groups,
+		"namespace", req.Namespace,
+		"pod", p.Name,
+		"workspaceId", p.Labels[config.WorkspaceIDLabel],
+		"allowed", resp.Allowed,
+		"reason", reason)
 }
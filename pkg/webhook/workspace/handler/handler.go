@@ -24,6 +24,9 @@ import (
 type WebhookHandler struct {
 	Client  client.Client
 	Decoder *admission.Decoder
+	// ControllerUsername is the username of the controller's service account, which is allowed to change objects
+	// owned by workspaces
+	ControllerUsername string
 }
 
 func (h *WebhookHandler) parse(req admission.Request, intoOld runtime.Object, intoNew runtime.Object) error {
@@ -0,0 +1,86 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/common"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// checkUserNamespace provisions the namespace of the user creating a workspace if namespace provisioning is enabled,
+// and returns an error pointing the user to their namespace if the workspace is created in another namespace.
+// Workspace admins may create workspaces in any namespace. The namespace is not provisioned for dry-run requests, as
+// the webhook is registered with SideEffects NoneOnDryRun.
+func (h *WebhookHandler) checkUserNamespace(ctx context.Context, req admission.Request) error {
+	if config.ControllerCfg.GetNamespaceProvisioningEnabled() != "true" || isWorkspaceAdmin(req.UserInfo) {
+		return nil
+	}
+	username := req.UserInfo.Username
+	namespaceName := common.UserNamespaceName(config.ControllerCfg.GetNamespaceTemplate(), username, req.UserInfo.UID)
+	if namespaceName == "" {
+		return fmt.Errorf("namespace for user '%s' cannot be named after template '%s'", username, config.ControllerCfg.GetNamespaceTemplate())
+	}
+	if req.DryRun == nil || !*req.DryRun {
+		if err := h.provisionUserNamespace(ctx, namespaceName, username); err != nil {
+			return err
+		}
+	}
+	if req.Namespace != namespaceName {
+		return fmt.Errorf("workspaces of user '%s' must be created in namespace '%s'", username, namespaceName)
+	}
+	return nil
+}
+
+// provisionUserNamespace creates the namespace of a user if it does not exist. The objects in the namespace are
+// provisioned by the namespace controller.
+func (h *WebhookHandler) provisionUserNamespace(ctx context.Context, name, username string) error {
+	namespace := &corev1.Namespace{}
+	err := h.Client.Get(ctx, types.NamespacedName{Name: name}, namespace)
+	if err == nil {
+		if namespace.Labels[config.UserNamespaceLabel] != "true" || namespace.Annotations[config.UserNamespaceUsernameAnnotation] != username {
+			return fmt.Errorf("namespace '%s' of user '%s' already exists and is not provisioned for the user. Notify an administrator about this issue", name, username)
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	labels, err := config.ControllerCfg.GetNamespaceLabels()
+	if err != nil {
+		return err
+	}
+	namespace = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+			Annotations: map[string]string{
+				config.UserNamespaceUsernameAnnotation: username,
+			},
+		},
+	}
+	if err := h.Client.Create(ctx, namespace); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return fmt.Errorf("namespace '%s' of user '%s' is being provisioned. Try again", name, username)
+		}
+		return err
+	}
+	log.Info("Provisioned namespace for user", "namespace", name, "user", username)
+	return nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// kubeControllerManagerUsername is the user the built-in Kubernetes controllers authenticate as when the controller
+	// manager is not run with --use-service-account-credentials
+	kubeControllerManagerUsername = "system:kube-controller-manager"
+	// kubeSystemServiceAccountsGroup is the group of the service accounts of the built-in Kubernetes controllers when
+	// the controller manager is run with --use-service-account-credentials
+	kubeSystemServiceAccountsGroup = "system:serviceaccounts:kube-system"
+)
+
+// ValidateOwnedObjectChange allows creating and updating objects owned by workspaces, such as workspace deployments,
+// services, routes, configmaps, components and workspace routings, only by the controller, by the built-in Kubernetes
+// controllers, which manage workspace replicasets and pods, and by the members of the workspace admin groups, so that
+// users cannot tamper with workspaces, e.g. to inject containers. When the controller runs locally, its username is
+// unknown and its changes are only allowed if it runs as a workspace admin.
+func (h *WebhookHandler) ValidateOwnedObjectChange(_ context.Context, req admission.Request) admission.Response {
+	if h.ControllerUsername != "" && req.UserInfo.Username == h.ControllerUsername {
+		return admission.Allowed("The workspace object is changed by the controller")
+	}
+	if isKubeController(req.UserInfo) {
+		return admission.Allowed("The workspace object is changed by a Kubernetes controller")
+	}
+	if isWorkspaceAdmin(req.UserInfo) {
+		return admission.Allowed("The workspace object is changed by an administrator")
+	}
+	log.Info("Denied change to workspace object",
+		"user", req.UserInfo.Username,
+		"operation", req.Operation,
+		"kind", req.Kind.Kind,
+		"namespace", req.Namespace,
+		"name", req.Name)
+	return admission.Denied(fmt.Sprintf("%s '%s' is managed by the workspace controller and can only be changed by the controller or administrators", req.Kind.Kind, req.Name))
+}
+
+// isWorkspaceAdmin returns true if the user is a member of one of the workspace admin groups of the controller config
+func isWorkspaceAdmin(user authenticationv1.UserInfo) bool {
+	for _, adminGroup := range config.ControllerCfg.GetWorkspaceAdminGroups() {
+		for _, group := range user.Groups {
+			if group == adminGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isKubeController returns true if the user is one of the built-in Kubernetes controllers
+func isKubeController(user authenticationv1.UserInfo) bool {
+	if user.Username == kubeControllerManagerUsername {
+		return true
+	}
+	for _, group := range user.Groups {
+		if group == kubeSystemServiceAccountsGroup {
+			return true
+		}
+	}
+	return false
+}
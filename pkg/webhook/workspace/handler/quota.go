@@ -0,0 +1,44 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/internal/quota"
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+)
+
+// checkQuota returns an error if a workspace is started although the quotas of its namespace or creator are exceeded
+// and the quota policy denies such starts. With the queue policy, the start is queued by the controller instead. As the
+// creator label may be missing from the new workspace on updates, quotas are checked for the old workspace then.
+func (h *WebhookHandler) checkQuota(ctx context.Context, oldWksp, newWksp *v1alpha1.Workspace) error {
+	if !newWksp.Spec.Started || config.ControllerCfg.GetQuotaPolicy() != config.QuotaPolicyDeny {
+		return nil
+	}
+	wksp := newWksp
+	if oldWksp != nil {
+		if oldWksp.Spec.Started {
+			return nil
+		}
+		wksp = oldWksp
+	}
+	exceeded, err := quota.Check(ctx, h.Client, wksp)
+	if err != nil {
+		return err
+	}
+	if exceeded != "" {
+		return fmt.Errorf("workspace '%s' cannot be started: %s", newWksp.Name, exceeded)
+	}
+	return nil
+}
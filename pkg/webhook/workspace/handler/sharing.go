@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// checkSharingAllowed verifies that the users and groups a workspace is shared with are only changed by the
+// workspace's creator
+func checkSharingAllowed(user authenticationv1.UserInfo, oldWksp, newWksp *v1alpha1.Workspace) error {
+	if cmp.Equal(oldWksp.Spec.SharedWith, newWksp.Spec.SharedWith, cmpopts.EquateEmpty()) {
+		return nil
+	}
+	if user.UID != oldWksp.Labels[config.WorkspaceCreatorLabel] {
+		return fmt.Errorf("only the workspace creator may change the users and groups the workspace is shared with")
+	}
+	return nil
+}
+
+// isSharedWith returns whether the user is listed in the workspace's shared users or is a member of one of the
+// workspace's shared groups
+func isSharedWith(wksp *v1alpha1.Workspace, user authenticationv1.UserInfo) bool {
+	sharing := wksp.Spec.SharedWith
+	if sharing == nil {
+		return false
+	}
+	for _, sharedUser := range sharing.Users {
+		if sharedUser == user.Username {
+			return true
+		}
+	}
+	for _, sharedGroup := range sharing.Groups {
+		for _, group := range user.Groups {
+			if sharedGroup == group {
+				return true
+			}
+		}
+	}
+	return false
+}
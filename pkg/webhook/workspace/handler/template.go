@@ -0,0 +1,62 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/devfile"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// checkTemplateAccess verifies that the user that creates or updates a workspace is allowed to get the workspace
+// template it uses, if the template is in a different namespace. Otherwise, the template's devfile would be disclosed
+// to users that cannot read it, since the controller reads templates on their behalf.
+func (h *WebhookHandler) checkTemplateAccess(ctx context.Context, req admission.Request, wksp *v1alpha1.Workspace) error {
+	templateRef, err := devfile.GetTemplateReference(wksp)
+	if err != nil {
+		// Invalid references are reported by the controller
+		return nil
+	}
+	if templateRef == nil || templateRef.Namespace == wksp.Namespace {
+		return nil
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: templateRef.Namespace,
+				Verb:      "get",
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  "workspacetemplates",
+				Name:      templateRef.Name,
+			},
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+			UID:    req.UserInfo.UID,
+		},
+	}
+	if err := h.Client.Create(ctx, review); err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user '%s' is not allowed to get workspace template %s/%s", req.UserInfo.Username, templateRef.Namespace, templateRef.Name)
+	}
+	return nil
+}
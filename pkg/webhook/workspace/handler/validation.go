@@ -0,0 +1,76 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/che-incubator/che-workspace-operator/pkg/devfile"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var supportedRoutingClasses = []string{
+	string(v1alpha1.WorkspaceRoutingDefault),
+	string(v1alpha1.WorkspaceRoutingOpenShiftOauth),
+	string(v1alpha1.WorkspaceRoutingCluster),
+	string(v1alpha1.WorkspaceRoutingClusterTLS),
+	string(v1alpha1.WorkspaceRoutingOpenShiftTerminal),
+}
+
+// ValidateWorkspace rejects workspaces whose spec would make them fail after being admitted. Updates that do not
+// change the spec other than starting or stopping the workspace are allowed, so that workspaces created before
+// validation was enforced can still be stopped.
+func (h *WebhookHandler) ValidateWorkspace(_ context.Context, req admission.Request) admission.Response {
+	wksp := &v1alpha1.Workspace{}
+	if req.Operation == v1beta1.Update {
+		oldWksp := &v1alpha1.Workspace{}
+		if err := h.parse(req, oldWksp, wksp); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if cmp.Equal(oldWksp.Spec, wksp.Spec, cmpopts.IgnoreFields(v1alpha1.WorkspaceSpec{}, "Started")) {
+			return admission.Allowed("workspace spec is unchanged")
+		}
+	} else if err := h.Decoder.Decode(req, wksp); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	errs := validateRoutingClass(wksp.Spec.RoutingClass, field.NewPath("spec", "routingClass"))
+	if gracePeriod := wksp.Spec.TerminationGracePeriodSeconds; gracePeriod != nil && *gracePeriod < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "terminationGracePeriodSeconds"), *gracePeriod, "must be non-negative"))
+	}
+	errs = append(errs, devfile.ValidateWorkspaceDevfile(wksp)...)
+	if len(errs) > 0 {
+		return admission.Denied(fmt.Sprintf("workspace '%s' is invalid: %s", wksp.Name, errs.ToAggregate().Error()))
+	}
+	return admission.Allowed("workspace is valid")
+}
+
+func validateRoutingClass(routingClass v1alpha1.WorkspaceRoutingClass, path *field.Path) field.ErrorList {
+	switch routingClass {
+	case "", v1alpha1.WorkspaceRoutingDefault, v1alpha1.WorkspaceRoutingCluster:
+		return nil
+	case v1alpha1.WorkspaceRoutingOpenShiftOauth, v1alpha1.WorkspaceRoutingClusterTLS, v1alpha1.WorkspaceRoutingOpenShiftTerminal:
+		if !config.ControllerCfg.IsOpenShift() {
+			return field.ErrorList{field.Invalid(path, routingClass, "routing class is only supported on OpenShift")}
+		}
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(path, routingClass, supportedRoutingClasses)}
+	}
+}
@@ -17,8 +17,10 @@ import (
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	"github.com/che-incubator/che-workspace-operator/internal/policy"
 	"github.com/che-incubator/che-workspace-operator/pkg/apis/workspace/v1alpha1"
 	"github.com/che-incubator/che-workspace-operator/pkg/config"
+	"github.com/che-incubator/che-workspace-operator/pkg/devfile"
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -33,7 +35,7 @@ var StopStartDiffOption = []cmp.Option{
 	cmpopts.IgnoreFields(v1alpha1.WorkspaceSpec{}, "Started"),
 }
 
-func (h *WebhookHandler) MutateWorkspaceOnCreate(_ context.Context, req admission.Request) admission.Response {
+func (h *WebhookHandler) MutateWorkspaceOnCreate(ctx context.Context, req admission.Request) admission.Response {
 	wksp := &v1alpha1.Workspace{}
 
 	err := h.Decoder.Decode(req, wksp)
@@ -41,40 +43,127 @@ func (h *WebhookHandler) MutateWorkspaceOnCreate(_ context.Context, req admissio
 		return admission.Errored(http.StatusBadRequest, err)
 	}
 
+	if err := h.checkTemplateAccess(ctx, req, wksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	if err := policy.CheckSchedulingAllowed(nil, wksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	if err := policy.CheckSecurityContextAllowed(nil, wksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	if wksp.Labels == nil {
 		wksp.Labels = map[string]string{}
 	}
 	wksp.Labels[config.WorkspaceCreatorLabel] = req.UserInfo.UID
+	if wksp.Annotations == nil {
+		wksp.Annotations = map[string]string{}
+	}
+	wksp.Annotations[config.WorkspaceCreatorUsernameAnnotation] = req.UserInfo.Username
+
+	if err := h.checkQuota(ctx, nil, wksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	// Checked last as the user namespace may be provisioned as a side effect
+	if err := h.checkUserNamespace(ctx, req); err != nil {
+		return admission.Denied(err.Error())
+	}
 	return h.returnPatched(req, wksp)
 }
 
-func (h *WebhookHandler) MutateWorkspaceOnUpdate(_ context.Context, req admission.Request) admission.Response {
+// MutateWorkspaceOnUpdate checks and mutates updated workspaces. Allowed changes of the workspace spec are recorded
+// as WorkspaceEvents and logged.
+func (h *WebhookHandler) MutateWorkspaceOnUpdate(ctx context.Context, req admission.Request) admission.Response {
 	newWksp := &v1alpha1.Workspace{}
 	oldWksp := &v1alpha1.Workspace{}
 	err := h.parse(req, oldWksp, newWksp)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
+
+	resp := h.mutateWorkspaceOnUpdate(ctx, req, oldWksp, newWksp)
+	if resp.Allowed {
+		h.recordWorkspaceUpdate(ctx, req, oldWksp, newWksp)
+	}
+	return resp
+}
+
+func (h *WebhookHandler) mutateWorkspaceOnUpdate(ctx context.Context, req admission.Request, oldWksp, newWksp *v1alpha1.Workspace) admission.Response {
+	if err := h.checkQuota(ctx, oldWksp, newWksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	immutable := oldWksp.Annotations[config.WorkspaceImmutableAnnotation]
 	if immutable == "true" {
 		return h.handleImmutableWorkspace(oldWksp, newWksp)
 	}
 
+	oldTemplateRef, _ := devfile.GetTemplateReference(oldWksp)
+	newTemplateRef, _ := devfile.GetTemplateReference(newWksp)
+	if !cmp.Equal(oldTemplateRef, newTemplateRef) {
+		if err := h.checkTemplateAccess(ctx, req, newWksp); err != nil {
+			return admission.Denied(err.Error())
+		}
+	}
+
+	if err := policy.CheckSchedulingAllowed(oldWksp, newWksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	if err := policy.CheckSecurityContextAllowed(oldWksp, newWksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
 	oldCreator, found := oldWksp.Labels[config.WorkspaceCreatorLabel]
 	if !found {
 		return admission.Denied(fmt.Sprintf("label '%s' is missing. Please recreate workspace to get it initialized", config.WorkspaceCreatorLabel))
 	}
 
+	if err := checkSharingAllowed(req.UserInfo, oldWksp, newWksp); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	patched := false
 	newCreator, found := newWksp.Labels[config.WorkspaceCreatorLabel]
 	if !found {
 		newWksp.Labels[config.WorkspaceCreatorLabel] = oldCreator
-		return h.returnPatched(req, newWksp)
+		patched = true
+	} else if newCreator != oldCreator {
+		return admission.Denied(fmt.Sprintf("label '%s' is assigned once workspace is created and is immutable", config.WorkspaceCreatorLabel))
 	}
 
-	if newCreator != oldCreator {
-		return admission.Denied(fmt.Sprintf("label '%s' is assigned once workspace is created and is immutable", config.WorkspaceCreatorLabel))
+	oldCreatorUsername, found := oldWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation]
+	if found {
+		newCreatorUsername, found := newWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation]
+		if !found {
+			if newWksp.Annotations == nil {
+				newWksp.Annotations = map[string]string{}
+			}
+			newWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation] = oldCreatorUsername
+			patched = true
+		} else if newCreatorUsername != oldCreatorUsername {
+			return admission.Denied(fmt.Sprintf("annotation '%s' is assigned once workspace is created and is immutable", config.WorkspaceCreatorUsernameAnnotation))
+		}
+	} else if req.UserInfo.UID == oldCreator {
+		// Workspaces created before the annotation was introduced are annotated on their creator's next update
+		if newWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation] != req.UserInfo.Username {
+			if newWksp.Annotations == nil {
+				newWksp.Annotations = map[string]string{}
+			}
+			newWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation] = req.UserInfo.Username
+			patched = true
+		}
+	} else if _, found := newWksp.Annotations[config.WorkspaceCreatorUsernameAnnotation]; found {
+		return admission.Denied(fmt.Sprintf("annotation '%s' may only be set by the workspace creator", config.WorkspaceCreatorUsernameAnnotation))
 	}
 
+	if patched {
+		return h.returnPatched(req, newWksp)
+	}
 	return admission.Allowed("new workspace has the same workspace as old one")
 }
 
@@ -19,30 +19,29 @@ import (
 )
 
 const (
-	mutateWebhookCfgName       = "workspace.che.eclipse.org"
+	mutateWebhookCfgName       = "mutate.workspace.che.eclipse.org"
 	mutateWebhookPath          = "/mutate"
 	mutateWebhookFailurePolicy = v1beta1.Fail
 )
 
 func buildMutateWebhookCfg() *v1beta1.MutatingWebhookConfiguration {
 	mutateWebhookFailurePolicy := mutateWebhookFailurePolicy
-	mutateWebhookPath := mutateWebhookPath
 	labelExistsOp := metav1.LabelSelectorOpExists
 	equivalentMatchPolicy := v1beta1.Equivalent
+	sideEffectsNoneOnDryRun := v1beta1.SideEffectClassNoneOnDryRun
 	return &v1beta1.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: mutateWebhookCfgName,
+			Labels: map[string]string{
+				config.WebhookConfigurationLabel: "true",
+			},
 		},
 		Webhooks: []v1beta1.MutatingWebhook{
 			{
 				Name:          "mutate.che-workspace-controller.svc",
 				FailurePolicy: &mutateWebhookFailurePolicy,
 				ClientConfig: v1beta1.WebhookClientConfig{
-					Service: &v1beta1.ServiceReference{
-						Name:      "workspace-controller",
-						Namespace: "che-workspace-controller",
-						Path:      &mutateWebhookPath,
-					},
+					Service:  server.GetServiceReference(mutateWebhookPath),
 					CABundle: server.CABundle,
 				},
 				Rules: []v1beta1.RuleWithOperations{
@@ -55,16 +54,16 @@ func buildMutateWebhookCfg() *v1beta1.MutatingWebhookConfiguration {
 						},
 					},
 				},
+				// Requests for other versions of the Workspace API are converted to v1alpha1 before being sent
+				MatchPolicy: &equivalentMatchPolicy,
+				// Workspace updates are recorded as WorkspaceEvents, except for dry-run requests
+				SideEffects: &sideEffectsNoneOnDryRun,
 			},
 			{
 				Name:          "mutate-ws-resources.che-workspace-controller.svc",
 				FailurePolicy: &mutateWebhookFailurePolicy,
 				ClientConfig: v1beta1.WebhookClientConfig{
-					Service: &v1beta1.ServiceReference{
-						Name:      "workspace-controller",
-						Namespace: "che-workspace-controller",
-						Path:      &mutateWebhookPath,
-					},
+					Service:  server.GetServiceReference(mutateWebhookPath),
 					CABundle: server.CABundle,
 				},
 				ObjectSelector: &metav1.LabelSelector{
@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/che-incubator/che-workspace-operator/pkg/webhook/workspace/handler"
+	"k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// OwnedObjectsValidator validates changes to the objects owned by workspaces
+type OwnedObjectsValidator struct {
+	*handler.WebhookHandler
+}
+
+func NewOwnedObjectsValidator(controllerUsername string) *OwnedObjectsValidator {
+	return &OwnedObjectsValidator{&handler.WebhookHandler{ControllerUsername: controllerUsername}}
+}
+
+func (v *OwnedObjectsValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == v1beta1.Create || req.Operation == v1beta1.Update {
+		return v.ValidateOwnedObjectChange(ctx, req)
+	}
+	// Do not allow operation if the corresponding handler is not found
+	// It indicates that the webhooks configuration is not a valid or incompatible with this version of controller
+	return admission.Denied(fmt.Sprintf("This admission controller is not designed to handle %s operation for %s. Notify an administrator about this issue", req.Operation, req.Kind))
+}
+
+// OwnedObjectsValidator implements inject.Client.
+// A client will be automatically injected.
+
+// InjectClient injects the client.
+func (v *OwnedObjectsValidator) InjectClient(c client.Client) error {
+	v.Client = c
+	return nil
+}
+
+// OwnedObjectsValidator implements admission.DecoderInjector.
+// A decoder will be automatically injected.
+
+// InjectDecoder injects the decoder.
+func (v *OwnedObjectsValidator) InjectDecoder(d *admission.Decoder) error {
+	v.Decoder = d
+	return nil
+}
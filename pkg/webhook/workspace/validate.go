@@ -16,6 +16,7 @@ import (
 
 	"github.com/che-incubator/che-workspace-operator/pkg/webhook/workspace/handler"
 	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -23,6 +24,7 @@ import (
 // ResourcesValidator validates execs process all exec requests and:
 // if related pod DOES NOT have workspace_id label - just skip it
 // if related pod DOES have workspace_id label - make sure that exec is requested by workspace creator
+// It also validates created and updated workspaces, so that invalid workspaces are rejected at admission time
 type ResourcesValidator struct {
 	*handler.WebhookHandler
 }
@@ -32,14 +34,26 @@ func NewResourcesValidator() *ResourcesValidator {
 }
 
 func (v *ResourcesValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-	if req.Kind == handler.V1PodExecOptionKind && req.Operation == v1beta1.Connect {
-		return v.ValidateExecOnConnect(ctx, req)
+	if isPodAccessKind(req.Kind) && req.Operation == v1beta1.Connect {
+		return v.ValidatePodAccess(ctx, req)
+	}
+	if req.Resource == podsResource && req.SubResource == handler.EphemeralContainersSubResource && req.Operation == v1beta1.Update {
+		return v.ValidatePodAccess(ctx, req)
+	}
+	if req.Kind == handler.V1alpha1WorkspaceKind && (req.Operation == v1beta1.Create || req.Operation == v1beta1.Update) {
+		return v.ValidateWorkspace(ctx, req)
 	}
 	// Do not allow operation if the corresponding handler is not found
 	// It indicates that the webhooks configuration is not a valid or incompatible with this version of controller
 	return admission.Denied(fmt.Sprintf("This admission controller is not designed to handle %s operation for %s. Notify an administrator about this issue", req.Operation, req.Kind))
 }
 
+var podsResource = metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+
+func isPodAccessKind(kind metav1.GroupVersionKind) bool {
+	return kind == handler.V1PodExecOptionKind || kind == handler.V1PodAttachOptionsKind || kind == handler.V1PodPortForwardOptionsKind
+}
+
 // WorkspaceMutator implements inject.Client.
 // A client will be automatically injected.
 
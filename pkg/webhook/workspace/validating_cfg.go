@@ -12,34 +12,35 @@
 package workspace
 
 import (
+	"github.com/che-incubator/che-workspace-operator/pkg/config"
 	"github.com/che-incubator/che-workspace-operator/pkg/webhook/server"
 	"k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	validateWebhookCfgName       = "workspace.che.eclipse.org"
+	validateWebhookCfgName       = "validate.workspace.che.eclipse.org"
 	validateWebhookPath          = "/validate"
+	validateOwnedWebhookPath     = "/validate-owned"
 	validateWebhookFailurePolicy = v1beta1.Fail
 )
 
 func buildValidatingWebhookCfg() *v1beta1.ValidatingWebhookConfiguration {
 	validateWebhookFailurePolicy := validateWebhookFailurePolicy
-	validateWebhookPath := validateWebhookPath
+	equivalentMatchPolicy := v1beta1.Equivalent
 	return &v1beta1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: validateWebhookCfgName,
+			Labels: map[string]string{
+				config.WebhookConfigurationLabel: "true",
+			},
 		},
 		Webhooks: []v1beta1.ValidatingWebhook{
 			{
 				Name:          "validate-exec.che-workspace-controller.svc",
 				FailurePolicy: &validateWebhookFailurePolicy,
 				ClientConfig: v1beta1.WebhookClientConfig{
-					Service: &v1beta1.ServiceReference{
-						Name:      "workspace-controller",
-						Namespace: "che-workspace-controller",
-						Path:      &validateWebhookPath,
-					},
+					Service:  server.GetServiceReference(validateWebhookPath),
 					CABundle: server.CABundle,
 				},
 				Rules: []v1beta1.RuleWithOperations{
@@ -48,7 +49,119 @@ func buildValidatingWebhookCfg() *v1beta1.ValidatingWebhookConfiguration {
 						Rule: v1beta1.Rule{
 							APIGroups:   []string{""},
 							APIVersions: []string{"v1"},
-							Resources:   []string{"pods/exec"},
+							Resources:   []string{"pods/exec", "pods/attach", "pods/portforward"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods/ephemeralcontainers"},
+						},
+					},
+				},
+			},
+			{
+				Name:          "validate-workspace.che-workspace-controller.svc",
+				FailurePolicy: &validateWebhookFailurePolicy,
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service:  server.GetServiceReference(validateWebhookPath),
+					CABundle: server.CABundle,
+				},
+				Rules: []v1beta1.RuleWithOperations{
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"workspace.che.eclipse.org"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"workspaces"},
+						},
+					},
+				},
+				// Requests for other versions of the Workspace API are converted to v1alpha1 before being sent
+				MatchPolicy: &equivalentMatchPolicy,
+			},
+			{
+				Name:          "validate-ws-owned.che-workspace-controller.svc",
+				FailurePolicy: &validateWebhookFailurePolicy,
+				ClientConfig: v1beta1.WebhookClientConfig{
+					Service:  server.GetServiceReference(validateOwnedWebhookPath),
+					CABundle: server.CABundle,
+				},
+				// Objects are owned by a workspace if they have the workspace ID label before or after the change
+				ObjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      config.WorkspaceIDLabel,
+							Operator: metav1.LabelSelectorOpExists,
+						},
+					},
+				},
+				MatchPolicy: &equivalentMatchPolicy,
+				Rules: []v1beta1.RuleWithOperations{
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{""},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"pods", "services", "configmaps", "secrets"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"apps"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"deployments", "replicasets"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"extensions", "networking.k8s.io"},
+							APIVersions: []string{"v1beta1"},
+							Resources:   []string{"ingresses"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"networking.k8s.io"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"networkpolicies"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"route.openshift.io"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"routes"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"oauth.openshift.io"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"oauthclients"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"rbac.authorization.k8s.io"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"roles", "rolebindings"},
+						},
+					},
+					{
+						Operations: []v1beta1.OperationType{v1beta1.Create, v1beta1.Update},
+						Rule: v1beta1.Rule{
+							APIGroups:   []string{"workspace.che.eclipse.org"},
+							APIVersions: []string{"v1alpha1"},
+							Resources:   []string{"components", "workspaceroutings", "workspaceevents"},
 						},
 					},
 				},
@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2019-2020 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+package workspace
+
+import (
+	"context"
+
+	"k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// webhookCfgReconciler restores the workspace webhook configurations when they are modified or deleted, and removes
+// stale webhook configurations when they are created, so that the webhooks served by the controller do not drift from
+// the ones configured in the cluster
+type webhookCfgReconciler struct {
+	client client.Client
+	ownRef *metav1.OwnerReference
+}
+
+var _ reconcile.Reconciler = &webhookCfgReconciler{}
+
+// addWebhookCfgController adds a controller that reconciles the workspace webhook configurations to mgr. All watched
+// configurations enqueue a single request, as they are all reconciled at once.
+func addWebhookCfgController(mgr manager.Manager, c client.Client, ownRef *metav1.OwnerReference) error {
+	ctrl, err := controller.New("webhookcfg-controller", mgr, controller.Options{
+		Reconciler: &webhookCfgReconciler{client: c, ownRef: ownRef},
+	})
+	if err != nil {
+		return err
+	}
+	webhookCfgHandler := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(handler.MapObject) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: mutateWebhookCfgName}}}
+		}),
+	}
+	webhookCfgPredicates := predicate.Funcs{
+		CreateFunc: func(evt event.CreateEvent) bool {
+			return isStaleWebhookCfg(evt.Object, false)
+		},
+		UpdateFunc: func(evt event.UpdateEvent) bool {
+			return isWorkspaceWebhookCfg(evt.ObjectOld) || isWorkspaceWebhookCfg(evt.ObjectNew)
+		},
+		DeleteFunc: func(evt event.DeleteEvent) bool {
+			return isWorkspaceWebhookCfg(evt.Object) && !isStaleWebhookCfg(evt.Object, false)
+		},
+		GenericFunc: func(evt event.GenericEvent) bool {
+			return false
+		},
+	}
+
+	err = ctrl.Watch(&source.Kind{Type: &v1beta1.MutatingWebhookConfiguration{}}, webhookCfgHandler, webhookCfgPredicates)
+	if err != nil {
+		return err
+	}
+	return ctrl.Watch(&source.Kind{Type: &v1beta1.ValidatingWebhookConfiguration{}}, webhookCfgHandler, webhookCfgPredicates)
+}
+
+func (r *webhookCfgReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.TODO()
+	if err := syncWebhookCfgs(ctx, r.client, r.ownRef); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, deleteStaleWebhookCfgs(ctx, r.client, false)
+}